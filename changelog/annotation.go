@@ -22,7 +22,7 @@ type Annotation struct {
 	ID string `json:"id" toml:"id" comment:"Annotation Identifier (DO NOT CHANGE)"`
 
 	// Indicates what category of Annotation was made. For example "feature" or "bugfix".
-	Type ChangeType `json:"type" toml:"type" comment:"Valid Types: announcement, release, feature, bugfix, documentation, or dependency"`
+	Type ChangeType `json:"type" toml:"type" comment:"Valid Types: announcement, security, breaking, release, feature, deprecation, bugfix, documentation, or dependency"`
 
 	// Collapse indicates that the change description should collapsed into a single item when summarizing changes across modules
 	Collapse bool `json:"collapse,omitempty" toml:"collapse" comment:"annotation should collapse as a summary in the CHANGELOG"`
@@ -32,6 +32,15 @@ type Annotation struct {
 
 	// The modules this change applies to
 	Modules []string `json:"modules"  toml:"modules" comment:"one or more relative module paths"`
+
+	// The numbers of the pull requests related to this change.
+	PullRequests []int `json:"pull_requests,omitempty" toml:"pull_requests" comment:"related pull request numbers (optional)"`
+
+	// The numbers of the issues related to this change.
+	Issues []int `json:"issues,omitempty" toml:"issues" comment:"related issue numbers (optional)"`
+
+	// The handles of the authors of this change.
+	Authors []string `json:"authors,omitempty" toml:"authors" comment:"author handles (optional)"`
 }
 
 // ValidationError is an error that indicates that one ore more issues are present for an annotation.
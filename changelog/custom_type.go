@@ -0,0 +1,102 @@
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+)
+
+// customChangeTypeOffset is the first ChangeType value assigned to repository-defined change types.
+const customChangeTypeOffset ChangeType = 100
+
+// customChangeType is a repository-defined change type.
+type customChangeType struct {
+	Name             string
+	ChangelogPrefix  string
+	VersionIncrement SemVerIncrement
+	Precedence       int
+}
+
+var customChangeTypes []customChangeType
+
+// ParseSemVerIncrement parses the given string v into a SemVerIncrement, returning an error if the string is invalid.
+func ParseSemVerIncrement(v string) (SemVerIncrement, error) {
+	switch strings.ToLower(v) {
+	case "", "default":
+		return DefaultBump, nil
+	case "patch":
+		return PatchBump, nil
+	case "minor":
+		return MinorBump, nil
+	case "release":
+		return ReleaseBump, nil
+	case "major":
+		return MajorBump, nil
+	default:
+		return DefaultBump, fmt.Errorf("unknown semver increment %q", v)
+	}
+}
+
+// RegisterChangeTypes registers the repository-defined change types so that they can be parsed, validated, and
+// rendered like the built-in change types. Any previously registered change types are replaced.
+func RegisterChangeTypes(types map[string]repotools.ChangeTypeConfig) error {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	registered := make([]customChangeType, 0, len(names))
+	for _, name := range names {
+		config := types[name]
+
+		if len(name) == 0 || strings.ContainsAny(name, " \t\n") {
+			return fmt.Errorf("invalid change type name %q", name)
+		}
+		if parseBuiltinChangeType(name) != UnknownChangeType {
+			return fmt.Errorf("change type %v conflicts with a built-in change type", name)
+		}
+		for _, ct := range registered {
+			if strings.EqualFold(ct.Name, name) {
+				return fmt.Errorf("change type %v is defined more than once", name)
+			}
+		}
+		if len(config.Heading) == 0 {
+			return fmt.Errorf("change type %v requires a heading", name)
+		}
+		increment, err := ParseSemVerIncrement(config.Increment)
+		if err != nil {
+			return fmt.Errorf("change type %v: %w", name, err)
+		}
+
+		registered = append(registered, customChangeType{
+			Name:             name,
+			ChangelogPrefix:  config.Heading,
+			VersionIncrement: increment,
+			Precedence:       config.Precedence,
+		})
+	}
+
+	customChangeTypes = registered
+
+	return nil
+}
+
+func lookupCustomChangeType(c ChangeType) (customChangeType, bool) {
+	i := int(c - customChangeTypeOffset)
+	if c < customChangeTypeOffset || i >= len(customChangeTypes) {
+		return customChangeType{}, false
+	}
+	return customChangeTypes[i], true
+}
+
+func parseCustomChangeType(v string) ChangeType {
+	for i, ct := range customChangeTypes {
+		if strings.EqualFold(ct.Name, v) {
+			return customChangeTypeOffset + ChangeType(i)
+		}
+	}
+	return UnknownChangeType
+}
@@ -0,0 +1,64 @@
+package changelog
+
+import (
+	"encoding/json"
+	"testing"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+)
+
+func TestRegisterChangeTypes(t *testing.T) {
+	t.Cleanup(func() {
+		customChangeTypes = nil
+	})
+
+	for name, types := range map[string]map[string]repotools.ChangeTypeConfig{
+		"built-in conflict": {"Feature": {Heading: "Feature"}},
+		"missing heading":   {"performance": {}},
+		"invalid increment": {"performance": {Heading: "Performance", Increment: "huge"}},
+		"invalid name":      {"perf change": {Heading: "Performance"}},
+	} {
+		if err := RegisterChangeTypes(types); err == nil {
+			t.Errorf("%v: expect error, got none", name)
+		}
+	}
+
+	if err := RegisterChangeTypes(map[string]repotools.ChangeTypeConfig{
+		"performance": {Heading: "Performance", Increment: "minor", Precedence: 55},
+		"internal":    {Heading: "Internal", Precedence: 5},
+	}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	c := ParseChangeType("Performance")
+	if c == UnknownChangeType {
+		t.Fatalf("expect performance change type to be registered")
+	}
+	if e, a := "performance", c.String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "Performance", c.ChangelogPrefix(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := MinorBump, c.VersionIncrement(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if !(c.Precedence() > FeatureChangeType.Precedence() && c.Precedence() < ReleaseChangeType.Precedence()) {
+		t.Errorf("expect performance to sort between feature and release, got %v", c.Precedence())
+	}
+
+	var a Annotation
+	if err := json.Unmarshal([]byte(`{"id":"a","type":"performance","description":"faster","modules":["."]}`), &a); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := Validate(a); err != nil {
+		t.Errorf("expect no error, got %v", err)
+	}
+
+	if err := RegisterChangeTypes(nil); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := UnknownChangeType, ParseChangeType("performance"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
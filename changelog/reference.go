@@ -0,0 +1,92 @@
+package changelog
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pullRequestReference matches the pull request number appended to the subject of a commit merged from a pull
+// request, for example "Fix a thing (#123)" or "Merge pull request #123 from ...".
+var pullRequestReference = regexp.MustCompile(`(?:\(#(\d+)\)\s*$|^Merge pull request #(\d+))`)
+
+// issueReference matches the closing keywords referencing an issue within a commit message, for example "Fixes #123".
+var issueReference = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+#(\d+)\b`)
+
+const noReplyEmailSuffix = "@users.noreply.github.com"
+
+// ParsePullRequestReferences returns the pull request numbers referenced by the subject line of the commit message.
+func ParsePullRequestReferences(message string) (numbers []int) {
+	subject := strings.SplitN(message, "\n", 2)[0]
+	for _, match := range pullRequestReference.FindAllStringSubmatch(subject, -1) {
+		for _, group := range match[1:] {
+			if n, err := strconv.Atoi(group); err == nil {
+				numbers = append(numbers, n)
+			}
+		}
+	}
+	return numbers
+}
+
+// ParseIssueReferences returns the issue numbers referenced by closing keywords within the commit message.
+func ParseIssueReferences(message string) (numbers []int) {
+	for _, match := range issueReference.FindAllStringSubmatch(message, -1) {
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// ParseAuthorHandle returns the author handle for the given commit author email. Only GitHub no-reply emails,
+// for example "123+handle@users.noreply.github.com", identify a handle. Returns false if no handle is identified.
+func ParseAuthorHandle(email string) (string, bool) {
+	if !strings.HasSuffix(strings.ToLower(email), noReplyEmailSuffix) {
+		return "", false
+	}
+	handle := email[:len(email)-len(noReplyEmailSuffix)]
+	if i := strings.LastIndex(handle, "+"); i >= 0 {
+		handle = handle[i+1:]
+	}
+	if len(handle) == 0 {
+		return "", false
+	}
+	return handle, true
+}
+
+// AddReferences adds the pull requests, issues, and author handles to the annotation, skipping any already present.
+// The references are kept in sorted order.
+func (a *Annotation) AddReferences(pullRequests, issues []int, authors []string) {
+	a.PullRequests = mergeInts(a.PullRequests, pullRequests)
+	a.Issues = mergeInts(a.Issues, issues)
+
+	seen := make(map[string]struct{})
+	for _, author := range a.Authors {
+		seen[author] = struct{}{}
+	}
+	for _, author := range authors {
+		if _, ok := seen[author]; ok {
+			continue
+		}
+		seen[author] = struct{}{}
+		a.Authors = append(a.Authors, author)
+	}
+	sort.Strings(a.Authors)
+}
+
+func mergeInts(v, add []int) []int {
+	seen := make(map[int]struct{})
+	for _, n := range v {
+		seen[n] = struct{}{}
+	}
+	for _, n := range add {
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		v = append(v, n)
+	}
+	sort.Ints(v)
+	return v
+}
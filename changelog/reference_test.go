@@ -0,0 +1,61 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePullRequestReferences(t *testing.T) {
+	cases := map[string][]int{
+		"Fix a thing (#12)":                                {12},
+		"Fix a thing (#12)\n\nFixes #3":                    {12},
+		"Merge pull request #34 from jdoe/feature":         {34},
+		"Mention (#12) in the middle":                      nil,
+		"service/ec2: Fix generation\n\nFollow up to (#7)": nil,
+	}
+
+	for message, want := range cases {
+		if diff := cmp.Diff(want, ParsePullRequestReferences(message)); len(diff) > 0 {
+			t.Errorf("%q: %s", message, diff)
+		}
+	}
+}
+
+func TestParseIssueReferences(t *testing.T) {
+	message := "Fix a thing (#12)\n\nFixes #3, closes #4\nresolved #5\nSee #6"
+	if diff := cmp.Diff([]int{3, 4, 5}, ParseIssueReferences(message)); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
+
+func TestParseAuthorHandle(t *testing.T) {
+	cases := map[string]struct {
+		handle string
+		ok     bool
+	}{
+		"123+jdoe@users.noreply.github.com": {handle: "jdoe", ok: true},
+		"jdoe@users.noreply.github.com":     {handle: "jdoe", ok: true},
+		"jdoe@example.com":                  {},
+	}
+	for email, want := range cases {
+		handle, ok := ParseAuthorHandle(email)
+		if want.handle != handle || want.ok != ok {
+			t.Errorf("%v: expect %v %v, got %v %v", email, want.handle, want.ok, handle, ok)
+		}
+	}
+}
+
+func TestAnnotation_AddReferences(t *testing.T) {
+	a := Annotation{PullRequests: []int{12}, Authors: []string{"jdoe"}}
+	a.AddReferences([]int{3, 12}, []int{4}, []string{"adoe", "jdoe"})
+
+	want := Annotation{
+		PullRequests: []int{3, 12},
+		Issues:       []int{4},
+		Authors:      []string{"adoe", "jdoe"},
+	}
+	if diff := cmp.Diff(want, a); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
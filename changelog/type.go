@@ -19,8 +19,27 @@ const (
 	MinorBump
 	// ReleaseBump indicates the module version should be updated from a pre-release tag.
 	ReleaseBump
+	// MajorBump indicates the module's version should be incremented by a major version bump. For modules at v1 or
+	// higher this requires the module path to be updated with the new major version suffix.
+	MajorBump
 )
 
+// String returns a string representation of the SemVerIncrement.
+func (s SemVerIncrement) String() string {
+	switch s {
+	case PatchBump:
+		return "patch"
+	case MinorBump:
+		return "minor"
+	case ReleaseBump:
+		return "release"
+	case MajorBump:
+		return "major"
+	default:
+		return "default"
+	}
+}
+
 // ChangeType describes the type of change made to a Go module.
 type ChangeType int
 
@@ -54,7 +73,8 @@ func (c *ChangeType) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
-// ChangeType in order from least to most precedence when generating changelog summaries
+// ChangeType constants of the built-in change types. The order changes are summarized in is defined by Precedence,
+// not the constant values.
 const (
 	UnknownChangeType ChangeType = iota
 	// DependencyChangeType is a constant change type for a dependency update.
@@ -63,16 +83,30 @@ const (
 	DocumentationChangeType
 	// BugFixChangeType is a constant change type for a bug fix.
 	BugFixChangeType
+	// DeprecationChangeType is a constant change type for the deprecation of a module's API or behavior.
+	DeprecationChangeType
 	// FeatureChangeType is a constant change type for a new feature.
 	FeatureChangeType
 	// ReleaseChangeType is a constant change type for a major version updates (from v0 => v1).
 	ReleaseChangeType
+	// BreakingChangeType is a constant change type for a backwards incompatible change requiring a new major version.
+	BreakingChangeType
+	// SecurityChangeType is a constant change type for a security fix.
+	SecurityChangeType
 	// AnnouncementChangeType is a constant change type for an SDK announcement.
 	AnnouncementChangeType
 )
 
-// ParseChangeType attempts to parse the given string v into a ChangeType, returning an error if the string is invalid.
+// ParseChangeType attempts to parse the given string v into a ChangeType, returning UnknownChangeType if the string
+// is not a built-in or registered repository-defined change type.
 func ParseChangeType(v string) ChangeType {
+	if c := parseBuiltinChangeType(v); c != UnknownChangeType {
+		return c
+	}
+	return parseCustomChangeType(v)
+}
+
+func parseBuiltinChangeType(v string) ChangeType {
 	switch {
 	case strings.EqualFold(FeatureChangeType.String(), v):
 		return FeatureChangeType
@@ -80,12 +114,18 @@ func ParseChangeType(v string) ChangeType {
 		return BugFixChangeType
 	case strings.EqualFold(ReleaseChangeType.String(), v):
 		return ReleaseChangeType
+	case strings.EqualFold(BreakingChangeType.String(), v):
+		return BreakingChangeType
 	case strings.EqualFold(DependencyChangeType.String(), v):
 		return DependencyChangeType
 	case strings.EqualFold(AnnouncementChangeType.String(), v):
 		return AnnouncementChangeType
 	case strings.EqualFold(DocumentationChangeType.String(), v):
 		return DocumentationChangeType
+	case strings.EqualFold(DeprecationChangeType.String(), v):
+		return DeprecationChangeType
+	case strings.EqualFold(SecurityChangeType.String(), v):
+		return SecurityChangeType
 	default:
 		return UnknownChangeType
 	}
@@ -100,24 +140,76 @@ func (c ChangeType) ChangelogPrefix() string {
 		return "Bug Fix"
 	case ReleaseChangeType:
 		return "Release"
+	case BreakingChangeType:
+		return "Breaking Change"
 	case DependencyChangeType:
 		return "Dependency Update"
 	case DocumentationChangeType:
 		return "Documentation"
 	case AnnouncementChangeType:
 		return "Announcement"
+	case DeprecationChangeType:
+		return "Deprecation"
+	case SecurityChangeType:
+		return "Security"
 	default:
+		if ct, ok := lookupCustomChangeType(c); ok {
+			return ct.ChangelogPrefix
+		}
 		return ""
 	}
 }
 
+// Precedence returns the order the ChangeType is sorted in when summarizing changes, higher precedence first.
+// The precedences of the built-in change types are fixed, and spaced apart, so that repository-defined change types
+// can be ordered between them.
+func (c ChangeType) Precedence() int {
+	switch c {
+	case AnnouncementChangeType:
+		return 90
+	case SecurityChangeType:
+		return 80
+	case BreakingChangeType:
+		return 70
+	case ReleaseChangeType:
+		return 60
+	case FeatureChangeType:
+		return 50
+	case DeprecationChangeType:
+		return 40
+	case BugFixChangeType:
+		return 30
+	case DocumentationChangeType:
+		return 20
+	case DependencyChangeType:
+		return 10
+	default:
+		if ct, ok := lookupCustomChangeType(c); ok {
+			return ct.Precedence
+		}
+		return 0
+	}
+}
+
+// IsNotice returns whether the ChangeType is a notice, (a security fix or deprecation), that is highlighted in its
+// own section when summarizing changes.
+func (c ChangeType) IsNotice() bool {
+	return c == SecurityChangeType || c == DeprecationChangeType
+}
+
 // VersionIncrement returns the SemVerIncrement corresponding to the given ChangeType.
 func (c ChangeType) VersionIncrement() SemVerIncrement {
 	switch c {
+	case BreakingChangeType:
+		return MajorBump
 	case ReleaseChangeType:
 		return ReleaseBump
 	case FeatureChangeType:
 		return MinorBump
+	case DeprecationChangeType:
+		return MinorBump
+	case SecurityChangeType:
+		return PatchBump
 	case BugFixChangeType:
 		return PatchBump
 	case DependencyChangeType:
@@ -125,8 +217,11 @@ func (c ChangeType) VersionIncrement() SemVerIncrement {
 	case DocumentationChangeType:
 		return PatchBump
 	case AnnouncementChangeType:
-		fallthrough
+		return DefaultBump
 	default:
+		if ct, ok := lookupCustomChangeType(c); ok {
+			return ct.VersionIncrement
+		}
 		return DefaultBump
 	}
 }
@@ -138,6 +233,8 @@ func (c ChangeType) String() string {
 		return "announcement"
 	case ReleaseChangeType:
 		return "release"
+	case BreakingChangeType:
+		return "breaking"
 	case FeatureChangeType:
 		return "feature"
 	case BugFixChangeType:
@@ -146,7 +243,14 @@ func (c ChangeType) String() string {
 		return "documentation"
 	case DependencyChangeType:
 		return "dependency"
+	case DeprecationChangeType:
+		return "deprecation"
+	case SecurityChangeType:
+		return "security"
 	default:
+		if ct, ok := lookupCustomChangeType(c); ok {
+			return ct.Name
+		}
 		return ""
 	}
 }
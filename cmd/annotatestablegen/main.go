@@ -50,6 +50,10 @@ func main() {
 		log.Fatalf("load config: %v", err)
 	}
 
+	if err := changelog.RegisterChangeTypes(cfg.Changelog.ChangeTypes); err != nil {
+		log.Fatalf("register change types: %v", err)
+	}
+
 	discoverer := gomod.NewDiscoverer(repoRoot)
 
 	if err := discoverer.Discover(); err != nil {
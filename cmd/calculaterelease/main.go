@@ -1,13 +1,11 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
+	"sort"
+	"strings"
 
 	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
@@ -16,6 +14,38 @@ import (
 	"github.com/awslabs/aws-go-multi-module-repository-tools/release"
 )
 
+// modulePatternsFlag is a comma separated list of module path patterns, and may be repeated.
+type modulePatternsFlag []string
+
+func (m *modulePatternsFlag) String() string {
+	return strings.Join(*m, ",")
+}
+
+func (m *modulePatternsFlag) Set(s string) error {
+	for _, pattern := range strings.Split(s, ",") {
+		if pattern = strings.TrimSpace(pattern); len(pattern) > 0 {
+			*m = append(*m, pattern)
+		}
+	}
+	return nil
+}
+
+// logDeferredModules logs the modules with changes that were deferred by the module filters.
+func logDeferredModules(modules map[string]*release.Module) {
+	var deferred []string
+	for _, module := range modules {
+		if module.DeferredChanges != 0 {
+			deferred = append(deferred, module.RelativeRepoPath)
+		}
+	}
+	sort.Strings(deferred)
+
+	for _, relPath := range deferred {
+		module, _ := release.FindModuleViaRelativeRepoPath(modules, relPath)
+		log.Printf("deferred module %v: %v", relPath, module.DeferredChanges)
+	}
+}
+
 type preReleaseFlag string
 
 func (p *preReleaseFlag) String() string {
@@ -30,11 +60,18 @@ func (p *preReleaseFlag) Set(s string) error {
 var preview preReleaseFlag
 var verbose bool
 var outputFile string
+var nativeGit bool
+var include, exclude modulePatternsFlag
+var versionLine string
 
 func init() {
 	flag.BoolVar(&verbose, "v", false, "output with verbose changes")
 	flag.Var(&preview, "preview", "indicates a semver pre-release should be calculated for all modules.")
 	flag.StringVar(&outputFile, "o", "", "output file")
+	flag.BoolVar(&nativeGit, "native-git", false, "read the git object database directly instead of invoking the git binary")
+	flag.Var(&include, "include", "comma separated relative module path patterns of the modules to release, defaults to all modules")
+	flag.Var(&exclude, "exclude", "comma separated relative module path patterns of the modules to not release")
+	flag.StringVar(&versionLine, "version-line", "", "major and minor version line, (e.g. v1.24), to release patch versions of")
 }
 
 func main() {
@@ -50,13 +87,27 @@ func main() {
 		log.Fatalf("failed to load repotools config: %v", err)
 	}
 
+	if err := changelog.RegisterChangeTypes(config.Changelog.ChangeTypes); err != nil {
+		log.Fatalf("failed to register change types: %v", err)
+	}
+
 	discoverer := gomod.NewDiscoverer(repoRoot)
 
 	if err := discoverer.Discover(); err != nil {
 		log.Fatalf("failed to discover repository modules: %v", err)
 	}
 
-	tags, err := git.Tags(repoRoot)
+	var repository git.Reader = git.NewExecRepository(repoRoot)
+	if nativeGit {
+		objectRepository, err := git.OpenRepository(repoRoot)
+		if err != nil {
+			log.Fatalf("failed to open git repository: %v", err)
+		}
+		defer objectRepository.Close()
+		repository = objectRepository
+	}
+
+	tags, err := repository.Tags()
 	if err != nil {
 		log.Fatalf("failed to get git tags: %v", err)
 	}
@@ -69,24 +120,34 @@ func main() {
 	}
 
 	log.Println("Calculating module changes")
-	modulesForRelease, err := release.Calculate(discoverer, taggedModules, config, annotations)
+	modulesForRelease, err := release.Calculate(discoverer, taggedModules, config, annotations, func(o *release.CalculateOptions) {
+		o.Repository = repository
+		o.Include = include
+		o.Exclude = exclude
+		o.VersionLine = versionLine
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	logDeferredModules(modulesForRelease)
+
 	id := release.NextReleaseID(tags)
 	manifest, err := release.BuildReleaseManifest(discoverer.Modules(), id, modulesForRelease, verbose, preview.String())
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	marshal, err := json.MarshalIndent(manifest, "", "    ")
-	if err != nil {
-		log.Fatal(err)
+	if len(versionLine) > 0 {
+		manifest.VersionLine, err = release.ParseVersionLine(versionLine)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	if len(outputFile) == 0 {
-		fmt.Printf("%v\n", string(marshal))
+		if err := release.WriteManifest(os.Stdout, manifest); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
@@ -100,7 +161,7 @@ func main() {
 		}
 	}()
 
-	if _, err = io.Copy(file, bytes.NewReader(marshal)); err != nil {
+	if err := release.WriteManifest(file, manifest); err != nil {
 		log.Fatal(err)
 	}
 }
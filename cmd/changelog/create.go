@@ -22,9 +22,13 @@ Options:
 -cs <tree-ish>   A starting commit or tag for a change annotation, must be used with -ce to compare changes between two trees
 -ce <tree-ish>   An ending commit or tag for a change annotation, must be used with -cs to compare changes between two trees
 -r               Declare that the annotation description should be rolled up as a summary when producing summarized CHANGELOG digests
--t <change-type> The change annotation type (release, feature, bugfix, dependency, announcement)
+-t <change-type> The change annotation type (security, breaking, release, feature, deprecation, bugfix, dependency, announcement)
 -d <description> The description of the change annotation, must be a string or a valid markdown list block
 -ni              Non-Interactive mode
+
+When a commit or commit range is provided, the pull requests, (from "(#123)" subject suffixes), issues, (from closing
+keywords like "Fixes #123"), and author handles, (from GitHub no-reply emails), of the commits are added to the
+annotation.
 `
 
 var createCommand = struct {
@@ -86,6 +90,7 @@ func runCreateCommand(args []string, repoRoot string) error {
 	}
 
 	var commitChanges []string
+	var commitLog []git.LogEntry
 
 	var err error
 	if createCommand.Commit != "" {
@@ -93,11 +98,19 @@ func runCreateCommand(args []string, repoRoot string) error {
 		if err != nil {
 			return fmt.Errorf("failed to get changed files for commit: %v", err)
 		}
+		commitLog, err = git.Log(repoRoot, "-1", createCommand.Commit)
+		if err != nil {
+			return fmt.Errorf("failed to get commit log: %v", err)
+		}
 	} else if createCommand.CommitStart != "" && createCommand.CommitEnd != "" {
 		commitChanges, err = git.Changes(repoRoot, createCommand.CommitStart, createCommand.CommitEnd)
 		if err != nil {
 			return fmt.Errorf("failed to get changed files for commit: %v", err)
 		}
+		commitLog, err = git.Log(repoRoot, createCommand.CommitStart+".."+createCommand.CommitEnd)
+		if err != nil {
+			return fmt.Errorf("failed to get commit log: %v", err)
+		}
 	}
 
 	if len(commitChanges) > 0 {
@@ -126,19 +139,9 @@ func runCreateCommand(args []string, repoRoot string) error {
 	}
 
 	if len(createCommand.DescriptionFile) > 0 {
-		if createCommand.DescriptionFile == "-" {
-			buff := &bytes.Buffer{}
-			_, err := io.Copy(buff, os.Stdin)
-			if err != nil {
-				return err
-			}
-			annotation.Description = buff.String()
-		} else {
-			fileBytes, err := os.ReadFile(createCommand.DescriptionFile)
-			if err != nil {
-				return err
-			}
-			annotation.Description = string(fileBytes)
+		annotation.Description, err = readDescriptionFile(createCommand.DescriptionFile)
+		if err != nil {
+			return err
 		}
 	} else {
 		annotation.Description = createCommand.Description
@@ -146,6 +149,8 @@ func runCreateCommand(args []string, repoRoot string) error {
 
 	annotation.Collapse = createCommand.Collapse
 
+	addCommitReferences(&annotation, commitLog)
+
 	if len(modulesToAnnotate) > 0 {
 		for moduleDir := range modulesToAnnotate {
 			annotation.Modules = append(annotation.Modules, moduleDir)
@@ -222,6 +227,38 @@ func interactiveEdit(annotation *changelog.Annotation, modules *gomod.ModuleTree
 	return nil
 }
 
+// readDescriptionFile reads an annotation description from the named file, or from stdin if the name is "-".
+func readDescriptionFile(name string) (string, error) {
+	if name == "-" {
+		buff := &bytes.Buffer{}
+		if _, err := io.Copy(buff, os.Stdin); err != nil {
+			return "", err
+		}
+		return buff.String(), nil
+	}
+
+	fileBytes, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return string(fileBytes), nil
+}
+
+// addCommitReferences adds the pull requests, issues, and author handles referenced by the commits to the annotation.
+func addCommitReferences(annotation *changelog.Annotation, commits []git.LogEntry) {
+	for _, commit := range commits {
+		var authors []string
+		if handle, ok := changelog.ParseAuthorHandle(commit.AuthorEmail); ok {
+			authors = append(authors, handle)
+		}
+		annotation.AddReferences(
+			changelog.ParsePullRequestReferences(commit.Message),
+			changelog.ParseIssueReferences(commit.Message),
+			authors,
+		)
+	}
+}
+
 func validateModules(input []string, modules *gomod.ModuleTree) (invalid []string) {
 	for _, moduleDir := range input {
 		if m := modules.Get(moduleDir); m == nil {
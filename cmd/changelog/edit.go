@@ -3,24 +3,83 @@ package main
 import (
 	"flag"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
 )
 
-const editHelpDoc = `changelog edit <id>
+const editHelpDoc = `changelog edit <id> [-t <change-type>] [-d <description> | -df <file>] [-r] [-add-module <module>...] [-rm-module <module>...]
+
+Options:
+-t <change-type>        The change annotation type
+-d <description>        The description of the change annotation, must be a string or a valid markdown list block
+-df <file>              A file to read the description of the change annotation from, or - to read from stdin
+-r                      Declare that the annotation description should be rolled up as a summary, -r=false to unset
+-add-module <module>    A module to add to the annotation, may be repeated
+-rm-module <module>     A module to remove from the annotation, may be repeated
+
+If no options are provided the annotation is edited interactively.
 `
 
+// stringSlice is a flag.Value that collects each occurrence of a repeated flag.
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set appends v to the slice.
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+var editCommand = struct {
+	Type ChangeType
+
+	Description     string
+	DescriptionFile string
+
+	Collapse bool
+
+	AddModules    stringSlice
+	RemoveModules stringSlice
+}{}
+
 var editFlagSet = func() *flag.FlagSet {
 	fs := flag.NewFlagSet("edit", flag.ExitOnError)
 	fs.Usage = func() {
 		fmt.Fprint(fs.Output(), editHelpDoc)
 	}
+	fs.Var(&editCommand.Type, "t", "")
+	fs.StringVar(&editCommand.Description, "d", "", "")
+	fs.StringVar(&editCommand.DescriptionFile, "df", "", "")
+	fs.BoolVar(&editCommand.Collapse, "r", false, "")
+	fs.Var(&editCommand.AddModules, "add-module", "")
+	fs.Var(&editCommand.RemoveModules, "rm-module", "")
 	return fs
 }()
 
+// parseInterspersed parses the flag set's flags which may appear before or after the positional arguments, returning
+// the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
 func runEditCommand(args []string, repoRoot string) error {
-	if err := editFlagSet.Parse(args); err != nil {
+	args, err := parseInterspersed(editFlagSet, args)
+	if err != nil {
 		return err
 	}
 
@@ -32,7 +91,6 @@ func runEditCommand(args []string, repoRoot string) error {
 
 	modules := discoverer.Modules()
 
-	args = editFlagSet.Args()
 	if len(args) == 0 || len(args) > 1 {
 		return fmt.Errorf("expect one annotation id to be provided")
 	}
@@ -42,9 +100,69 @@ func runEditCommand(args []string, repoRoot string) error {
 		return err
 	}
 
-	if err := interactiveEdit(&annotation, modules); err != nil {
+	set := make(map[string]bool)
+	editFlagSet.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if len(set) == 0 {
+		if err := interactiveEdit(&annotation, modules); err != nil {
+			return err
+		}
+		return changelog.WriteAnnotation(repoRoot, annotation)
+	}
+
+	if set["d"] && set["df"] {
+		return fmt.Errorf("only one of -d or -df can be specified")
+	}
+
+	if set["t"] {
+		annotation.Type = changelog.ChangeType(editCommand.Type)
+	}
+
+	if set["d"] {
+		annotation.Description = editCommand.Description
+	} else if set["df"] {
+		annotation.Description, err = readDescriptionFile(editCommand.DescriptionFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if set["r"] {
+		annotation.Collapse = editCommand.Collapse
+	}
+
+	if invalid := validateModules(editCommand.AddModules, modules); len(invalid) > 0 {
+		return fmt.Errorf("unknown modules: %v", invalid)
+	}
+	annotation.Modules = editModules(annotation.Modules, editCommand.AddModules, editCommand.RemoveModules)
+
+	if err := changelog.Validate(annotation); err != nil {
 		return err
 	}
 
 	return changelog.WriteAnnotation(repoRoot, annotation)
 }
+
+// editModules returns the sorted module list with the add modules included and the remove modules excluded.
+func editModules(modules, add, remove []string) []string {
+	set := make(map[string]struct{})
+	for _, moduleDir := range modules {
+		set[moduleDir] = struct{}{}
+	}
+	for _, moduleDir := range add {
+		set[moduleDir] = struct{}{}
+	}
+	for _, moduleDir := range remove {
+		delete(set, moduleDir)
+	}
+
+	edited := make([]string, 0, len(set))
+	for moduleDir := range set {
+		edited = append(edited, moduleDir)
+	}
+	sort.Strings(edited)
+
+	return edited
+}
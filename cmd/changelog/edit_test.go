@@ -0,0 +1,175 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseInterspersed(t *testing.T) {
+	cases := map[string]struct {
+		Args             []string
+		ExpectPositional []string
+		ExpectModules    []string
+		ExpectDesc       string
+		ExpectErr        string
+	}{
+		"flags before positional": {
+			Args:             []string{"-d", "desc", "id1"},
+			ExpectPositional: []string{"id1"},
+			ExpectDesc:       "desc",
+		},
+		"flags after positional": {
+			Args:             []string{"id1", "-d", "desc"},
+			ExpectPositional: []string{"id1"},
+			ExpectDesc:       "desc",
+		},
+		"flags mixed with positional": {
+			Args:             []string{"-m", "a", "id1", "-d", "desc", "id2", "-m", "b"},
+			ExpectPositional: []string{"id1", "id2"},
+			ExpectModules:    []string{"a", "b"},
+			ExpectDesc:       "desc",
+		},
+		"repeated module flag": {
+			Args:             []string{"id1", "-m", "a", "-m", "b", "-m", "a"},
+			ExpectPositional: []string{"id1"},
+			ExpectModules:    []string{"a", "b", "a"},
+		},
+		"no positional": {
+			Args:          []string{"-m", "a"},
+			ExpectModules: []string{"a"},
+		},
+		"unknown flag": {
+			Args:      []string{"id1", "-x"},
+			ExpectErr: "flag provided but not defined: -x",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var modules stringSlice
+			var desc string
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+			fs.Var(&modules, "m", "")
+			fs.StringVar(&desc, "d", "", "")
+
+			positional, err := parseInterspersed(fs, tt.Args)
+			if len(tt.ExpectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if diff := cmp.Diff(tt.ExpectPositional, positional); len(diff) > 0 {
+				t.Errorf("positional: %s", diff)
+			}
+			if diff := cmp.Diff(tt.ExpectModules, []string(modules)); len(diff) > 0 {
+				t.Errorf("modules: %s", diff)
+			}
+			if e, a := tt.ExpectDesc, desc; e != a {
+				t.Errorf("expect %q description, got %q", e, a)
+			}
+		})
+	}
+}
+
+func Test_editModules(t *testing.T) {
+	cases := map[string]struct {
+		Modules []string
+		Add     []string
+		Remove  []string
+		Expect  []string
+	}{
+		"unchanged": {
+			Modules: []string{"b", "a"},
+			Expect:  []string{"a", "b"},
+		},
+		"add": {
+			Modules: []string{"a"},
+			Add:     []string{"c", "b", "a"},
+			Expect:  []string{"a", "b", "c"},
+		},
+		"remove": {
+			Modules: []string{"a", "b"},
+			Remove:  []string{"b", "missing"},
+			Expect:  []string{"a"},
+		},
+		"add and remove same module": {
+			Modules: []string{"a"},
+			Add:     []string{"b"},
+			Remove:  []string{"b"},
+			Expect:  []string{"a"},
+		},
+		"remove all": {
+			Modules: []string{"a"},
+			Remove:  []string{"a"},
+			Expect:  []string{},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := editModules(tt.Modules, tt.Add, tt.Remove)
+			if diff := cmp.Diff(tt.Expect, actual); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_readDescriptionFile(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "description.md")
+	if err := os.WriteFile(existing, []byte("* first\n* second\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		Name      string
+		Expect    string
+		ExpectErr bool
+	}{
+		"file": {
+			Name:   existing,
+			Expect: "* first\n* second\n",
+		},
+		"missing file": {
+			Name:      filepath.Join(dir, "missing.md"),
+			ExpectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := readDescriptionFile(tt.Name)
+			if tt.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if !os.IsNotExist(err) {
+					t.Errorf("expect not exist error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := tt.Expect, actual; e != a {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+		})
+	}
+}
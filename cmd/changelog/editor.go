@@ -8,8 +8,11 @@ import (
 	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
 )
 
+// editorConfig is the repository's configuration of the editor used for interactive edits.
+var editorConfig repotools.EditorConfig
+
 func editTemplate(template []byte) ([]byte, error) {
-	editor, err := repotools.GetEditorTool()
+	editor, err := repotools.GetEditorCommand(editorConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +28,11 @@ func editTemplate(template []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	cmd := exec.Command(editor, f.Name())
+	if err = f.Close(); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/release"
+)
+
+const lintHelpDoc = `changelog lint
+
+Reports modules with source changes since their last tagged release that are not covered by an annotation,
+annotations that refer to modules that do not exist, and annotations with duplicate descriptions. Exits with a
+non-zero status if any issues are found.
+`
+
+var lintFlagSet = func() *flag.FlagSet {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), lintHelpDoc)
+	}
+	return fs
+}()
+
+func runLintCommand(args []string, repoRoot string) error {
+	if err := lintFlagSet.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := repotools.LoadConfig(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load repotools config: %w", err)
+	}
+
+	discoverer := gomod.NewDiscoverer(repoRoot)
+	if err := discoverer.Discover(); err != nil {
+		return fmt.Errorf("failed to discover repository modules: %w", err)
+	}
+
+	tags, err := git.Tags(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get git tags: %w", err)
+	}
+
+	annotations, err := changelog.GetAnnotations(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	modules, err := release.Calculate(discoverer, git.ParseModuleTags(tags), config, annotations)
+	if err != nil {
+		return err
+	}
+
+	issues := release.LintAnnotations(modules, annotations)
+	for _, issue := range issues {
+		fmt.Fprintln(os.Stdout, issue)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d changelog annotation issues", len(issues))
+	}
+
+	return nil
+}
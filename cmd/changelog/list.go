@@ -1,22 +1,56 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
-	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
-	"github.com/olekukonko/tablewriter"
 	"os"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/olekukonko/tablewriter"
 )
 
-const listHelpDoc = `changelog ls
+const listHelpDoc = `changelog ls [-t <change-type>...] [-m <module>...] [-collapse] [-sort <field>] [-modules] [-json]
+
+Options:
+-t <change-type>  Only list annotations of the change type, may be repeated
+-m <module>       Only list annotations for the module path or glob pattern, (e.g. service/*), may be repeated
+-collapse         Only list annotations that are rolled up as a summary, -collapse=false for those that are not
+-sort <field>     Sort annotations by id, type, or description (default id)
+-modules          List each annotation's modules instead of the number of modules
+-json             Output the annotations as a JSON array
+
+Module patterns are matched against the module directory using Go's path.Match, where * does not match /, so
+service/* matches service/s3 but not service/s3/internal; use -m service/* -m service/*/* to include nested modules.
 `
 
+var listCommand = struct {
+	Types   stringSlice
+	Modules stringSlice
+
+	Collapse bool
+
+	Sort string
+
+	ListModules bool
+	JSON        bool
+}{}
+
 var listFlagSet = func() *flag.FlagSet {
 	fs := flag.NewFlagSet("ls", flag.ExitOnError)
 	fs.Usage = func() {
 		fmt.Fprint(fs.Output(), listHelpDoc)
 	}
+	fs.Var(&listCommand.Types, "t", "")
+	fs.Var(&listCommand.Modules, "m", "")
+	fs.BoolVar(&listCommand.Collapse, "collapse", false, "")
+	fs.StringVar(&listCommand.Sort, "sort", "id", "")
+	fs.BoolVar(&listCommand.ListModules, "modules", false, "")
+	fs.BoolVar(&listCommand.JSON, "json", false, "")
 	return fs
 }()
 
@@ -25,20 +59,144 @@ func runListCommand(args []string, repoRoot string) error {
 		return err
 	}
 
+	var collapseFilter bool
+	listFlagSet.Visit(func(f *flag.Flag) {
+		if f.Name == "collapse" {
+			collapseFilter = true
+		}
+	})
+
+	var types []changelog.ChangeType
+	for _, t := range listCommand.Types {
+		ct := changelog.ParseChangeType(t)
+		if ct == changelog.UnknownChangeType {
+			return fmt.Errorf("unknown change type: %v", t)
+		}
+		types = append(types, ct)
+	}
+
+	for _, pattern := range listCommand.Modules {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid module pattern %v: %w", pattern, err)
+		}
+	}
+
 	annotations, err := changelog.GetAnnotations(repoRoot)
 	if err != nil {
 		return err
 	}
 
+	var collapse *bool
+	if collapseFilter {
+		collapse = &listCommand.Collapse
+	}
+	annotations = filterListAnnotations(annotations, types, listCommand.Modules, collapse)
+
+	if err := sortListAnnotations(annotations, listCommand.Sort); err != nil {
+		return err
+	}
+
+	if listCommand.JSON {
+		if annotations == nil {
+			annotations = []changelog.Annotation{}
+		}
+		marshal, err := json.MarshalIndent(annotations, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(os.Stdout, "%s\n", marshal)
+		return err
+	}
+
 	table := tablewriter.NewWriter(os.Stdout)
 
 	table.SetHeader([]string{"ID", "Type", "Modules", "Collapse", "Description"})
 
 	for _, annotation := range annotations {
-		table.Append([]string{annotation.ID, annotation.Type.String(), strconv.Itoa(len(annotation.Modules)), strconv.FormatBool(annotation.Collapse), annotation.Description})
+		modules := strconv.Itoa(len(annotation.Modules))
+		if listCommand.ListModules {
+			modules = strings.Join(annotation.Modules, "\n")
+		}
+		table.Append([]string{annotation.ID, annotation.Type.String(), modules, strconv.FormatBool(annotation.Collapse), annotation.Description})
 	}
 
 	table.Render()
 
 	return nil
 }
+
+// filterListAnnotations returns the annotations of one of the types, if any, for a module matching one of the module
+// patterns, if any, and with the collapse value, if not nil.
+func filterListAnnotations(annotations []changelog.Annotation, types []changelog.ChangeType, modulePatterns []string, collapse *bool) []changelog.Annotation {
+	filtered := annotations[:0]
+	for _, annotation := range annotations {
+		if len(types) > 0 && !containsChangeType(types, annotation.Type) {
+			continue
+		}
+		if len(modulePatterns) > 0 && !matchesAnyModule(modulePatterns, annotation.Modules) {
+			continue
+		}
+		if collapse != nil && annotation.Collapse != *collapse {
+			continue
+		}
+		filtered = append(filtered, annotation)
+	}
+	return filtered
+}
+
+func containsChangeType(types []changelog.ChangeType, t changelog.ChangeType) bool {
+	for _, ct := range types {
+		if ct == t {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyModule returns whether any of the modules match one of the module paths or glob patterns. Patterns are
+// matched with path.Match, so * does not match across directories, (e.g. service/* does not match service/s3/sub).
+func matchesAnyModule(patterns []string, modules []string) bool {
+	for _, pattern := range patterns {
+		for _, moduleDir := range modules {
+			if ok, _ := path.Match(pattern, moduleDir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortListAnnotations sorts the annotations by the given field. Annotations are sorted by type from highest to lowest
+// precedence.
+func sortListAnnotations(annotations []changelog.Annotation, field string) error {
+	var less func(a, b changelog.Annotation) bool
+
+	switch strings.ToLower(field) {
+	case "id":
+		less = func(a, b changelog.Annotation) bool {
+			return a.ID < b.ID
+		}
+	case "type":
+		less = func(a, b changelog.Annotation) bool {
+			if pa, pb := a.Type.Precedence(), b.Type.Precedence(); pa != pb {
+				return pa > pb
+			}
+			return a.ID < b.ID
+		}
+	case "description":
+		less = func(a, b changelog.Annotation) bool {
+			if a.Description != b.Description {
+				return a.Description < b.Description
+			}
+			return a.ID < b.ID
+		}
+	default:
+		return fmt.Errorf("unknown sort field: %v", field)
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return less(annotations[i], annotations[j])
+	})
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_matchesAnyModule(t *testing.T) {
+	cases := map[string]struct {
+		Patterns []string
+		Modules  []string
+		Expect   bool
+	}{
+		"exact module":          {Patterns: []string{"service/s3"}, Modules: []string{"core", "service/s3"}, Expect: true},
+		"no match":              {Patterns: []string{"service/s3"}, Modules: []string{"core"}},
+		"glob":                  {Patterns: []string{"service/*"}, Modules: []string{"service/s3"}, Expect: true},
+		"glob nested module":    {Patterns: []string{"service/*"}, Modules: []string{"service/s3/sub"}},
+		"nested glob":           {Patterns: []string{"service/*", "service/*/*"}, Modules: []string{"service/s3/sub"}, Expect: true},
+		"root module":           {Patterns: []string{"."}, Modules: []string{"."}, Expect: true},
+		"glob not root":         {Patterns: []string{"*"}, Modules: []string{"service/s3"}},
+		"no annotation modules": {Patterns: []string{"*"}},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := tt.Expect, matchesAnyModule(tt.Patterns, tt.Modules); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func Test_filterListAnnotations(t *testing.T) {
+	annotations := func() []changelog.Annotation {
+		return []changelog.Annotation{
+			{ID: "a", Type: changelog.FeatureChangeType, Modules: []string{"service/s3"}},
+			{ID: "b", Type: changelog.BugFixChangeType, Modules: []string{"service/s3/sub"}, Collapse: true},
+			{ID: "c", Type: changelog.BugFixChangeType, Modules: []string{"core"}},
+		}
+	}
+	yes, no := true, false
+
+	cases := map[string]struct {
+		Types    []changelog.ChangeType
+		Patterns []string
+		Collapse *bool
+		Expect   []string
+	}{
+		"no filters": {
+			Expect: []string{"a", "b", "c"},
+		},
+		"type": {
+			Types:  []changelog.ChangeType{changelog.BugFixChangeType},
+			Expect: []string{"b", "c"},
+		},
+		"module pattern": {
+			Patterns: []string{"service/*"},
+			Expect:   []string{"a"},
+		},
+		"nested module pattern": {
+			Patterns: []string{"service/*", "service/*/*"},
+			Expect:   []string{"a", "b"},
+		},
+		"collapse": {
+			Collapse: &yes,
+			Expect:   []string{"b"},
+		},
+		"not collapse": {
+			Collapse: &no,
+			Expect:   []string{"a", "c"},
+		},
+		"combined": {
+			Types:    []changelog.ChangeType{changelog.BugFixChangeType},
+			Patterns: []string{"core", "service/*/*"},
+			Collapse: &no,
+			Expect:   []string{"c"},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			for _, annotation := range filterListAnnotations(annotations(), tt.Types, tt.Patterns, tt.Collapse) {
+				actual = append(actual, annotation.ID)
+			}
+			if diff := cmp.Diff(tt.Expect, actual); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_sortListAnnotations(t *testing.T) {
+	annotations := func() []changelog.Annotation {
+		return []changelog.Annotation{
+			{ID: "c", Type: changelog.BugFixChangeType, Description: "alpha"},
+			{ID: "a", Type: changelog.FeatureChangeType, Description: "beta"},
+			{ID: "d", Type: changelog.BreakingChangeType, Description: "alpha"},
+			{ID: "b", Type: changelog.BugFixChangeType, Description: "gamma"},
+		}
+	}
+
+	cases := map[string]struct {
+		Field     string
+		Expect    []string
+		ExpectErr string
+	}{
+		"id": {
+			Field:  "id",
+			Expect: []string{"a", "b", "c", "d"},
+		},
+		"type": {
+			Field:  "type",
+			Expect: []string{"d", "a", "b", "c"},
+		},
+		"description": {
+			Field:  "Description",
+			Expect: []string{"c", "d", "a", "b"},
+		},
+		"unknown field": {
+			Field:     "modules",
+			ExpectErr: "unknown sort field: modules",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			sorted := annotations()
+			err := sortListAnnotations(sorted, tt.Field)
+			if len(tt.ExpectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var actual []string
+			for _, annotation := range sorted {
+				actual = append(actual, annotation.ID)
+			}
+			if diff := cmp.Diff(tt.Expect, actual); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -37,6 +37,17 @@ func main() {
 		log.Fatalf("failed to get repository root: %v", err)
 	}
 
+	config, err := repotools.LoadConfig(repoRoot)
+	if err != nil {
+		log.Fatalf("failed to load repotools config: %v", err)
+	}
+
+	if err := changelog.RegisterChangeTypes(config.Changelog.ChangeTypes); err != nil {
+		log.Fatalf("failed to register change types: %v", err)
+	}
+
+	editorConfig = config.Editor
+
 	arg := flag.Arg(0)
 
 	switch {
@@ -50,6 +61,8 @@ func main() {
 		err = runEditCommand(flag.Args()[1:], repoRoot)
 	case strings.EqualFold(arg, removeFlagSet.Name()):
 		err = runRemoveCommand(flag.Args()[1:], repoRoot)
+	case strings.EqualFold(arg, lintFlagSet.Name()):
+		err = runLintCommand(flag.Args()[1:], repoRoot)
 	case strings.EqualFold(arg, "help") || len(arg) == 0:
 		fallthrough
 	default:
@@ -73,6 +86,8 @@ func printHelp() {
 	builder.WriteRune('\n')
 	builder.WriteString(viewHelpDoc)
 	builder.WriteRune('\n')
+	builder.WriteString(lintHelpDoc)
+	builder.WriteRune('\n')
 	fmt.Fprint(os.Stderr, builder.String())
 	os.Exit(0)
 }
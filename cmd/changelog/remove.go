@@ -18,7 +18,7 @@ var removeCommand = struct {
 var removeFlagSet = func() *flag.FlagSet {
 	fs := flag.NewFlagSet("rm", flag.ExitOnError)
 	fs.Usage = func() {
-		fmt.Fprint(fs.Output(), editHelpDoc)
+		fmt.Fprint(fs.Output(), removeHelpDoc)
 	}
 	fs.BoolVar(&removeCommand.All, "all", false, "")
 	return fs
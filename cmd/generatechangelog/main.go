@@ -2,12 +2,13 @@ package main
 
 import (
 	"bytes"
-	"encoding/json"
 	"flag"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 
@@ -18,11 +19,16 @@ import (
 
 const changeLogFile = "CHANGELOG.md"
 
-var releaseManifestFile, summaryNotesFile string
+var releaseManifestFile, summaryNotesFile, summaryNotesLink, summaryNotesAuthor string
+
+var summaryNotesFormat = markdownNotesFormat
 
 func init() {
 	flag.StringVar(&releaseManifestFile, "release", "", "release manifest file")
 	flag.StringVar(&summaryNotesFile, "o", "", "indicates that a copy of the changelog notes should be written to the target file")
+	flag.Var(&summaryNotesFormat, "format", "format of the -o changelog notes: markdown, json, atom, or rss")
+	flag.StringVar(&summaryNotesLink, "link", "", "URL of the release notes to link to from atom and rss feeds, required for those formats")
+	flag.StringVar(&summaryNotesAuthor, "author", "", "name of the atom feed author, defaults to the repository directory name")
 }
 
 func main() {
@@ -32,7 +38,11 @@ func main() {
 		log.Fatalln("first argument should be a release manifest file")
 	}
 
-	manifest, err := loadManifest(releaseManifestFile)
+	if len(summaryNotesFile) > 0 && isFeedNotesFormat(summaryNotesFormat) && len(summaryNotesLink) == 0 {
+		log.Fatalf("-link is required for %v notes", summaryNotesFormat)
+	}
+
+	manifest, err := release.LoadManifest(releaseManifestFile)
 	if err != nil {
 		log.Fatalf("failed to load release manifest file: %v", err)
 	}
@@ -52,25 +62,46 @@ func main() {
 		log.Fatalf("failed to get git repository root: %v", err)
 	}
 
+	config, err := repotools.LoadConfig(repoRoot)
+	if err != nil {
+		log.Fatalf("failed to load repotools config: %v", err)
+	}
+
+	if err := changelog.RegisterChangeTypes(config.Changelog.ChangeTypes); err != nil {
+		log.Fatalf("failed to register change types: %v", err)
+	}
+
 	annotations, err := changelog.GetAnnotations(repoRoot)
 	if err != nil {
 		log.Fatalf("failed to get change annotations: %v", err)
 	}
 
+	if err := loadTemplates(repoRoot, config.Changelog); err != nil {
+		log.Fatalf("failed to load changelog templates: %v", err)
+	}
+
 	annotations = filterUnreferencedAnnotations(manifest, annotations)
 
 	summary, err := generateSummary(manifest, annotations)
 	if err != nil {
 		log.Fatalf("failed to generate summary: %v", err)
 	}
+	summary.ChangelogPath = config.Changelog.RepositoryChangelogPath()
+	summary.RootModuleChangelog = config.Changelog.RootModuleChangelog()
+	summary.setRepositoryURL(config.Changelog.RepositoryURL)
 
-	if err := writeRepoChangeLogEntry(repoRoot, summary); err != nil {
-		log.Fatalf("failed to write summary CHANGELOG.md")
+	if err := checkRepositoryChangelogPath(repoRoot, summary.ChangelogPath); err != nil {
+		log.Fatalf("invalid changelog repository_path: %v", err)
+	}
+
+	repoChangelogPath := filepath.Join(repoRoot, filepath.FromSlash(summary.ChangelogPath))
+	if err := writeRepoChangeLogEntry(repoChangelogPath, summary); err != nil {
+		log.Fatalf("failed to write summary %v: %v", summary.ChangelogPath, err)
 	}
 
 	for moduleDir, ms := range summary.Modules {
-		if moduleDir == "." {
-			// The root module contains the repository changelog
+		if moduleDir == "." && !summary.RootModuleChangelog {
+			// The root module's CHANGELOG.md is the repository changelog
 			continue
 		}
 		if err = writeModuleChangeLog(filepath.Join(repoRoot, moduleDir), ms); err != nil {
@@ -79,12 +110,34 @@ func main() {
 	}
 
 	if len(summaryNotesFile) > 0 {
-		if err := writeSummaryNotes(summaryNotesFile, summary); err != nil {
+		author := summaryNotesAuthor
+		if len(author) == 0 {
+			author = filepath.Base(repoRoot)
+		}
+		if err := writeSummaryNotes(summaryNotesFile, summaryNotesFormat, summary, summaryNotesLink, author); err != nil {
 			log.Fatalf("failed to write summary notes: %v", err)
 		}
 	}
 }
 
+// checkRepositoryChangelogPath returns an error if the repository release summary CHANGELOG would overwrite the
+// CHANGELOG.md of a module other than the root module.
+func checkRepositoryChangelogPath(repoRoot, changelogPath string) error {
+	dir, file := path.Split(changelogPath)
+	dir = path.Clean(dir)
+	if file != changeLogFile || dir == "." {
+		return nil
+	}
+
+	if _, err := os.Stat(filepath.Join(repoRoot, filepath.FromSlash(dir), "go.mod")); err == nil {
+		return fmt.Errorf("%v is the CHANGELOG of the module at %v", changelogPath, dir)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
 func copyToTempFile(name string) (io.ReadSeeker, func() error, error) {
 	if _, err := os.Stat(name); err != nil && os.IsNotExist(err) {
 		return bytes.NewReader(nil), func() error {
@@ -143,27 +196,15 @@ func filterUnreferencedAnnotations(manifest release.Manifest, annotations []chan
 	return filtered
 }
 
-func loadManifest(path string) (v release.Manifest, err error) {
-	fb, err := ioutil.ReadFile(path)
-	if err != nil {
-		return release.Manifest{}, err
-	}
-
-	if err = json.Unmarshal(fb, &v); err != nil {
-		return release.Manifest{}, err
-	}
-
-	return v, nil
-}
-
-// sortAnnotations sorts from their highest numerical order to lowest
+// sortAnnotations sorts from their highest precedence to lowest
 func sortAnnotations(annotations []changelog.Annotation) {
 	sort.Slice(annotations, func(i, j int) bool {
-		if annotations[i].Type < annotations[j].Type {
-			return false
+		pi, pj := annotations[i].Type.Precedence(), annotations[j].Type.Precedence()
+		if pi != pj {
+			return pi > pj
 		}
 		if annotations[i].Type != annotations[j].Type {
-			return true
+			return annotations[i].Type.String() < annotations[j].Type.String()
 		}
 		return annotations[i].Description < annotations[j].Description
 	})
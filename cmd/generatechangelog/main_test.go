@@ -1,10 +1,14 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/release"
 	"github.com/google/go-cmp/cmp"
-	"testing"
 )
 
 func Test_sortAnnotations(t *testing.T) {
@@ -16,12 +20,18 @@ func Test_sortAnnotations(t *testing.T) {
 		{Type: changelog.FeatureChangeType, Description: "e"},
 		{Type: changelog.ReleaseChangeType, Description: "f"},
 		{Type: changelog.AnnouncementChangeType, Description: "g"},
+		{Type: changelog.SecurityChangeType, Description: "h"},
+		{Type: changelog.DeprecationChangeType, Description: "i"},
+		{Type: changelog.BreakingChangeType, Description: "j"},
 	}
 
 	want := []changelog.Annotation{
 		{Type: changelog.AnnouncementChangeType, Description: "g"},
+		{Type: changelog.SecurityChangeType, Description: "h"},
+		{Type: changelog.BreakingChangeType, Description: "j"},
 		{Type: changelog.ReleaseChangeType, Description: "f"},
 		{Type: changelog.FeatureChangeType, Description: "e"},
+		{Type: changelog.DeprecationChangeType, Description: "i"},
 		{Type: changelog.BugFixChangeType, Description: "d"},
 		{Type: changelog.DocumentationChangeType, Description: "c"},
 		{Type: changelog.DependencyChangeType, Description: "a"},
@@ -35,6 +45,51 @@ func Test_sortAnnotations(t *testing.T) {
 	}
 }
 
+func Test_sortAnnotationsCustomTypes(t *testing.T) {
+	t.Cleanup(func() {
+		if err := changelog.RegisterChangeTypes(nil); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	})
+	if err := changelog.RegisterChangeTypes(map[string]repotools.ChangeTypeConfig{
+		"performance": {Heading: "Performance", Precedence: 55},
+		"internal":    {Heading: "Internal", Precedence: 5},
+		"urgent":      {Heading: "Urgent", Precedence: 95},
+	}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	performance := changelog.ParseChangeType("performance")
+	internal := changelog.ParseChangeType("internal")
+	urgent := changelog.ParseChangeType("urgent")
+
+	annotations := []changelog.Annotation{
+		{Type: internal, Description: "a"},
+		{Type: changelog.DependencyChangeType, Description: "b"},
+		{Type: changelog.FeatureChangeType, Description: "c"},
+		{Type: performance, Description: "d"},
+		{Type: changelog.ReleaseChangeType, Description: "e"},
+		{Type: changelog.AnnouncementChangeType, Description: "f"},
+		{Type: urgent, Description: "g"},
+	}
+
+	want := []changelog.Annotation{
+		{Type: urgent, Description: "g"},
+		{Type: changelog.AnnouncementChangeType, Description: "f"},
+		{Type: changelog.ReleaseChangeType, Description: "e"},
+		{Type: performance, Description: "d"},
+		{Type: changelog.FeatureChangeType, Description: "c"},
+		{Type: changelog.DependencyChangeType, Description: "b"},
+		{Type: internal, Description: "a"},
+	}
+
+	sortAnnotations(annotations)
+
+	if diff := cmp.Diff(want, annotations); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
+
 func Test_filterUnreferencedAnnotations(t *testing.T) {
 	manifest := release.Manifest{
 		Modules: map[string]release.ModuleManifest{
@@ -56,3 +111,38 @@ func Test_filterUnreferencedAnnotations(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func Test_checkRepositoryChangelogPath(t *testing.T) {
+	repoRoot := t.TempDir()
+	for _, dir := range []string{"", "service/s3"} {
+		if err := os.MkdirAll(filepath.Join(repoRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repoRoot, dir, "go.mod"), []byte("module example.com/repo\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(repoRoot, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		ChangelogPath string
+		ExpectErr     bool
+	}{
+		"root changelog":          {ChangelogPath: "CHANGELOG.md"},
+		"other file":              {ChangelogPath: "RELEASES.md"},
+		"non-module directory":    {ChangelogPath: "docs/CHANGELOG.md"},
+		"module directory, other": {ChangelogPath: "service/s3/RELEASES.md"},
+		"module changelog":        {ChangelogPath: "service/s3/CHANGELOG.md", ExpectErr: true},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkRepositoryChangelogPath(repoRoot, tt.ChangelogPath)
+			if tt.ExpectErr != (err != nil) {
+				t.Errorf("expect error %v, got %v", tt.ExpectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+)
+
+// notesFormat is the output format of the release notes written with -o.
+type notesFormat string
+
+const (
+	markdownNotesFormat notesFormat = "markdown"
+	jsonNotesFormat     notesFormat = "json"
+	atomNotesFormat     notesFormat = "atom"
+	rssNotesFormat      notesFormat = "rss"
+)
+
+// Set validates and sets the notes format, satisfying flag.Value.
+func (f *notesFormat) Set(v string) error {
+	switch nf := notesFormat(strings.ToLower(v)); nf {
+	case markdownNotesFormat, jsonNotesFormat, atomNotesFormat, rssNotesFormat:
+		*f = nf
+		return nil
+	default:
+		return fmt.Errorf("unknown notes format %q, expect markdown, json, atom, or rss", v)
+	}
+}
+
+// String returns the notes format, satisfying flag.Value.
+func (f *notesFormat) String() string {
+	return string(*f)
+}
+
+// isFeedNotesFormat returns whether the notes format is a feed, which requires a link to the release notes.
+func isFeedNotesFormat(f notesFormat) bool {
+	return f == atomNotesFormat || f == rssNotesFormat
+}
+
+// releaseNotes is the structured representation of a release summary.
+type releaseNotes struct {
+	ReleaseID string        `json:"release_id"`
+	General   []changeNote  `json:"general,omitempty"`
+	Modules   []moduleNotes `json:"modules"`
+}
+
+// moduleNotes are the release notes for a single module.
+type moduleNotes struct {
+	ModuleDir  string       `json:"module_dir"`
+	ModulePath string       `json:"module_path"`
+	Version    string       `json:"version"`
+	Changes    []changeNote `json:"changes,omitempty"`
+}
+
+// changeNote is a single change annotation of a release.
+type changeNote struct {
+	ID          string               `json:"id,omitempty"`
+	Type        changelog.ChangeType `json:"type"`
+	Description string               `json:"description"`
+
+	PullRequests []int    `json:"pull_requests,omitempty"`
+	Issues       []int    `json:"issues,omitempty"`
+	Authors      []string `json:"authors,omitempty"`
+}
+
+func newChangeNotes(annotations []changelog.Annotation) (notes []changeNote) {
+	for _, a := range annotations {
+		notes = append(notes, changeNote{
+			ID:          a.ID,
+			Type:        a.Type,
+			Description: a.Description,
+
+			PullRequests: a.PullRequests,
+			Issues:       a.Issues,
+			Authors:      a.Authors,
+		})
+	}
+	return notes
+}
+
+// newReleaseNotes returns the structured release notes for the summary, with modules sorted by their directory.
+func newReleaseNotes(summary releaseSummary) releaseNotes {
+	notes := releaseNotes{
+		ReleaseID: summary.ReleaseID,
+		General:   newChangeNotes(summary.General),
+		Modules:   make([]moduleNotes, 0, len(summary.Modules)),
+	}
+
+	for moduleDir, ms := range summary.Modules {
+		notes.Modules = append(notes.Modules, moduleNotes{
+			ModuleDir:  moduleDir,
+			ModulePath: ms.ModulePath,
+			Version:    ms.Version,
+			Changes:    newChangeNotes(ms.Annotations),
+		})
+	}
+	sort.Slice(notes.Modules, func(i, j int) bool {
+		return notes.Modules[i].ModuleDir < notes.Modules[j].ModuleDir
+	})
+
+	return notes
+}
+
+// Text returns a plain text listing of the release notes suitable for feed entry content.
+func (r releaseNotes) Text() string {
+	var sb strings.Builder
+
+	for _, c := range r.General {
+		fmt.Fprintf(&sb, "* %s: %s\n", c.Type.ChangelogPrefix(), c.Description)
+	}
+	if len(r.General) > 0 && len(r.Modules) > 0 {
+		sb.WriteRune('\n')
+	}
+
+	for _, m := range r.Modules {
+		fmt.Fprintf(&sb, "* %s: %s\n", m.ModulePath, m.Version)
+		for _, c := range m.Changes {
+			fmt.Fprintf(&sb, "  * %s: %s\n", c.Type.ChangelogPrefix(), c.Description)
+		}
+	}
+
+	return sb.String()
+}
+
+func executeJSONNotes(wr io.Writer, summary releaseSummary) error {
+	encoder := json.NewEncoder(wr)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(newReleaseNotes(summary))
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+// atomAuthor is the feed author, required by RFC 4287 for feeds whose entries do not have their own author.
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// feedEntryID returns the unique identifier of the release's feed entry, using the link as the base of the identifier.
+func feedEntryID(link, releaseID string) string {
+	return strings.TrimSuffix(link, "#") + "#" + releaseID
+}
+
+func executeAtomNotes(wr io.Writer, summary releaseSummary, link, author string, updated time.Time) error {
+	if len(link) == 0 {
+		return fmt.Errorf("atom notes require a link")
+	}
+	if len(author) == 0 {
+		return fmt.Errorf("atom notes require an author")
+	}
+
+	notes := newReleaseNotes(summary)
+	title := fmt.Sprintf("Release (%s)", notes.ReleaseID)
+	ts := updated.UTC().Format(time.RFC3339)
+
+	return encodeXML(wr, atomFeed{
+		Title:   title,
+		ID:      link,
+		Updated: ts,
+		Link:    atomLink{Href: link},
+		Author:  atomAuthor{Name: author},
+		Entries: []atomEntry{{
+			Title:   title,
+			ID:      feedEntryID(link, notes.ReleaseID),
+			Updated: ts,
+			Link:    atomLink{Href: link},
+			Content: atomContent{Type: "text", Body: notes.Text()},
+		}},
+	})
+}
+
+func executeRSSNotes(wr io.Writer, summary releaseSummary, link string, updated time.Time) error {
+	if len(link) == 0 {
+		return fmt.Errorf("rss notes require a link")
+	}
+
+	notes := newReleaseNotes(summary)
+	title := fmt.Sprintf("Release (%s)", notes.ReleaseID)
+
+	return encodeXML(wr, rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       title,
+			Link:        link,
+			Description: title,
+			Items: []rssItem{{
+				Title:       title,
+				Link:        link,
+				GUID:        rssGUID{Value: feedEntryID(link, notes.ReleaseID)},
+				PubDate:     updated.UTC().Format(time.RFC1123Z),
+				Description: notes.Text(),
+			}},
+		},
+	})
+}
+
+func encodeXML(wr io.Writer, v interface{}) error {
+	if _, err := io.WriteString(wr, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(wr)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(wr, "\n")
+	return err
+}
+
+// executeNotes writes the release summary notes in the given format. The link, author, and updated time are only used
+// by the feed formats, which require the link.
+func executeNotes(wr io.Writer, format notesFormat, summary releaseSummary, link, author string, updated time.Time) error {
+	switch format {
+	case jsonNotesFormat:
+		return executeJSONNotes(wr, summary)
+	case atomNotesFormat:
+		return executeAtomNotes(wr, summary, link, author, updated)
+	case rssNotesFormat:
+		return executeRSSNotes(wr, summary, link, updated)
+	default:
+		return executeSummaryNotesTemplate(wr, summary)
+	}
+}
+
+func writeSummaryNotes(path string, format notesFormat, summary releaseSummary, link, author string) (err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := f.Close()
+		if err == nil && fErr != nil {
+			err = fErr
+		}
+	}()
+
+	return executeNotes(f, format, summary, link, author, time.Now())
+}
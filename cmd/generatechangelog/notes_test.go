@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_executeNotes(t *testing.T) {
+	summary := releaseSummary{
+		ReleaseID: "2021-05-05",
+		General: []changelog.Annotation{
+			{ID: "a", Type: changelog.FeatureChangeType, Collapse: true, Description: "a & b"},
+		},
+		Modules: map[string]moduleSummary{
+			"e/f": {
+				ReleaseID:  "2021-05-05",
+				ModulePath: "a/b/e/f",
+				Version:    "v1.0.1",
+				Annotations: []changelog.Annotation{
+					{ID: "c", Type: changelog.BugFixChangeType, Description: "c"},
+				},
+			},
+			".": {
+				ReleaseID:  "2021-05-05",
+				ModulePath: "a/b",
+				Version:    "v1.1.0",
+				Annotations: []changelog.Annotation{
+					{ID: "a", Type: changelog.FeatureChangeType, Collapse: true, Description: "a & b"},
+				},
+			},
+		},
+	}
+	updated := time.Date(2021, 5, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		format    notesFormat
+		link      string
+		want      string
+		expectErr string
+	}{
+		"json": {
+			format: jsonNotesFormat,
+			want: `{
+    "release_id": "2021-05-05",
+    "general": [
+        {
+            "id": "a",
+            "type": "feature",
+            "description": "a & b"
+        }
+    ],
+    "modules": [
+        {
+            "module_dir": ".",
+            "module_path": "a/b",
+            "version": "v1.1.0",
+            "changes": [
+                {
+                    "id": "a",
+                    "type": "feature",
+                    "description": "a & b"
+                }
+            ]
+        },
+        {
+            "module_dir": "e/f",
+            "module_path": "a/b/e/f",
+            "version": "v1.0.1",
+            "changes": [
+                {
+                    "id": "c",
+                    "type": "bugfix",
+                    "description": "c"
+                }
+            ]
+        }
+    ]
+}
+`,
+		},
+		"atom": {
+			format: atomNotesFormat,
+			link:   "https://example.com/CHANGELOG.md",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Release (2021-05-05)</title>
+  <id>https://example.com/CHANGELOG.md</id>
+  <updated>2021-05-05T12:00:00Z</updated>
+  <link href="https://example.com/CHANGELOG.md"></link>
+  <author>
+    <name>example-repo</name>
+  </author>
+  <entry>
+    <title>Release (2021-05-05)</title>
+    <id>https://example.com/CHANGELOG.md#2021-05-05</id>
+    <updated>2021-05-05T12:00:00Z</updated>
+    <link href="https://example.com/CHANGELOG.md"></link>
+    <content type="text">* Feature: a &amp; b&#xA;&#xA;* a/b: v1.1.0&#xA;  * Feature: a &amp; b&#xA;* a/b/e/f: v1.0.1&#xA;  * Bug Fix: c&#xA;</content>
+  </entry>
+</feed>
+`,
+		},
+		"rss": {
+			format: rssNotesFormat,
+			link:   "https://example.com/CHANGELOG.md",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Release (2021-05-05)</title>
+    <link>https://example.com/CHANGELOG.md</link>
+    <description>Release (2021-05-05)</description>
+    <item>
+      <title>Release (2021-05-05)</title>
+      <link>https://example.com/CHANGELOG.md</link>
+      <guid isPermaLink="false">https://example.com/CHANGELOG.md#2021-05-05</guid>
+      <pubDate>Wed, 05 May 2021 12:00:00 +0000</pubDate>
+      <description>* Feature: a &amp; b&#xA;&#xA;* a/b: v1.1.0&#xA;  * Feature: a &amp; b&#xA;* a/b/e/f: v1.0.1&#xA;  * Bug Fix: c&#xA;</description>
+    </item>
+  </channel>
+</rss>
+`,
+		},
+		"atom without link": {
+			format:    atomNotesFormat,
+			expectErr: "atom notes require a link",
+		},
+		"rss without link": {
+			format:    rssNotesFormat,
+			expectErr: "rss notes require a link",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := bytes.NewBuffer(nil)
+			err := executeNotes(wr, tt.format, summary, tt.link, "example-repo", updated)
+			if len(tt.expectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.expectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.want, wr.String()); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_notesFormat_Set(t *testing.T) {
+	var f notesFormat
+	if err := f.Set("JSON"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := jsonNotesFormat, f; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if err := f.Set("yaml"); err == nil {
+		t.Error("expect error, got none")
+	}
+}
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/release"
 )
@@ -53,8 +58,10 @@ func executeRepoChangeLogEntryTemplate(wr io.Writer, summary releaseSummary) err
 	return repoChangeLogTemplate.ExecuteTemplate(wr, "entry", summary)
 }
 
-func writeRepoChangeLogEntry(path string, summary releaseSummary) (err error) {
-	changelogPath := filepath.Join(path, changeLogFile)
+func writeRepoChangeLogEntry(changelogPath string, summary releaseSummary) (err error) {
+	if err := os.MkdirAll(filepath.Dir(changelogPath), 0755); err != nil {
+		return err
+	}
 
 	t, cleanup, err := copyToTempFile(changelogPath)
 	if err != nil {
@@ -93,36 +100,110 @@ func executeSummaryNotesTemplate(wr io.Writer, summary releaseSummary) error {
 	return repoChangeLogTemplate.ExecuteTemplate(wr, "summary", summary)
 }
 
-func writeSummaryNotes(path string, summary releaseSummary) (err error) {
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		fErr := f.Close()
-		if err == nil && fErr != nil {
-			err = fErr
-		}
-	}()
-
-	if err = executeSummaryNotesTemplate(f, summary); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 type moduleSummary struct {
 	ReleaseID   string
 	ModulePath  string
 	Version     string
 	Annotations []changelog.Annotation
+
+	// The URL of the repository used to link annotation references.
+	RepositoryURL string
+}
+
+// References returns the pull requests, issues, and authors referenced by the annotation, formatted to be appended
+// to the annotation's description.
+func (m moduleSummary) References(annotation changelog.Annotation) string {
+	return annotationReferences(m.RepositoryURL, annotation)
 }
 
 type releaseSummary struct {
 	ReleaseID string
 	General   []changelog.Annotation
 	Modules   map[string]moduleSummary
+
+	// The slash separated path of the repository changelog relative to the repository root. Defaults to the root
+	// CHANGELOG.md if not set.
+	ChangelogPath string
+
+	// Indicates the root module has its own CHANGELOG.md that can be linked to.
+	RootModuleChangelog bool
+
+	// The URL of the repository used to link annotation references.
+	RepositoryURL string
+}
+
+// setRepositoryURL sets the repository URL used to link annotation references for the release and each module.
+func (r *releaseSummary) setRepositoryURL(repositoryURL string) {
+	r.RepositoryURL = repositoryURL
+	for moduleDir, ms := range r.Modules {
+		ms.RepositoryURL = repositoryURL
+		r.Modules[moduleDir] = ms
+	}
+}
+
+// References returns the pull requests, issues, and authors referenced by the annotation, formatted to be appended
+// to the annotation's description.
+func (r releaseSummary) References(annotation changelog.Annotation) string {
+	return annotationReferences(r.RepositoryURL, annotation)
+}
+
+// annotationReferences returns the annotation's references as a parenthesized list, prefixed with a space. The
+// references are markdown links if the repository URL is known. Returns an empty string if the annotation has no
+// references.
+func annotationReferences(repositoryURL string, annotation changelog.Annotation) string {
+	repositoryURL = strings.TrimSuffix(repositoryURL, "/")
+
+	var authorURL string
+	if u, err := url.Parse(repositoryURL); err == nil && len(u.Host) > 0 {
+		authorURL = (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+	}
+
+	var refs []string
+	for _, n := range annotation.PullRequests {
+		refs = append(refs, referenceLink(fmt.Sprintf("#%d", n), repositoryURL, fmt.Sprintf("pull/%d", n)))
+	}
+	for _, n := range annotation.Issues {
+		refs = append(refs, referenceLink(fmt.Sprintf("#%d", n), repositoryURL, fmt.Sprintf("issues/%d", n)))
+	}
+	for _, author := range annotation.Authors {
+		refs = append(refs, referenceLink("@"+author, authorURL, author))
+	}
+
+	if len(refs) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(refs, ", ") + ")"
+}
+
+func referenceLink(text, baseURL, path string) string {
+	if len(baseURL) == 0 {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s/%s)", text, baseURL, path)
+}
+
+// ModuleChangelogLink returns a markdown link to the module's changelog entry relative to the repository changelog.
+// The version is returned without a link if the module does not have its own changelog.
+func (r releaseSummary) ModuleChangelogLink(relModDir string, summary moduleSummary) string {
+	if relModDir == "." && !r.RootModuleChangelog {
+		return summary.Version
+	}
+
+	changelogPath := r.ChangelogPath
+	if len(changelogPath) == 0 {
+		changelogPath = repotools.DefaultRepositoryChangelogPath
+	}
+
+	target := path.Join(relModDir, changeLogFile)
+	if rel, err := filepath.Rel(filepath.FromSlash(path.Dir(changelogPath)), filepath.FromSlash(target)); err == nil {
+		target = filepath.ToSlash(rel)
+	}
+
+	lv := strings.ReplaceAll(summary.Version, ".", "")
+	lr := strings.ReplaceAll(summary.ReleaseID, ".", "")
+
+	return fmt.Sprintf("[%s](%s#%s)", summary.Version, target, strings.ToLower(lv+"-"+lr))
 }
 
 func (r releaseSummary) IsEmptyReleaseSummary() bool {
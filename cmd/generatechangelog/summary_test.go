@@ -198,6 +198,63 @@ func Test_executeRepoChangeLogEntryTemplate(t *testing.T) {
 ## General Highlights
 * **Feature**: a
 
+`,
+		},
+		"security and deprecation notices": {
+			summary: releaseSummary{
+				ReleaseID: "2021-05-05",
+				General: []changelog.Annotation{
+					{
+						Type:        changelog.DeprecationChangeType,
+						Collapse:    true,
+						Description: "a",
+					},
+				},
+				Modules: map[string]moduleSummary{
+					"c/d": {
+						ReleaseID:  "2021-05-05",
+						ModulePath: "a/b/c/d",
+						Version:    "v1.1.1",
+						Annotations: func() (v []changelog.Annotation) {
+							v = []changelog.Annotation{
+								{
+									Type:        changelog.BugFixChangeType,
+									Description: "b",
+								},
+								{
+									Type:        changelog.SecurityChangeType,
+									Description: "c",
+								},
+							}
+							sortAnnotations(v)
+							return v
+						}(),
+					},
+					"e/f": {
+						ReleaseID:  "2021-05-05",
+						ModulePath: "a/b/e/f",
+						Version:    "v1.1.0",
+						Annotations: []changelog.Annotation{
+							{
+								Type:        changelog.DeprecationChangeType,
+								Collapse:    true,
+								Description: "a",
+							},
+						},
+					},
+				},
+			},
+			wantWr: `# Release (2021-05-05)
+
+## Security and Deprecation Notices
+* **Deprecation**: a
+* ` + "`a/b/c/d`" + `: [v1.1.1](c/d/CHANGELOG.md#v111-2021-05-05)
+  * **Security**: c
+
+## Module Highlights
+* ` + "`a/b/c/d`" + `: [v1.1.1](c/d/CHANGELOG.md#v111-2021-05-05)
+  * **Bug Fix**: b
+
 `,
 		},
 		"module highlights only": {
@@ -223,6 +280,46 @@ func Test_executeRepoChangeLogEntryTemplate(t *testing.T) {
 * ` + "`a/b/c/d`" + `: [v1.1.0](c/d/CHANGELOG.md#v110-2021-05-05)
   * **Feature**: a
 
+`,
+		},
+		"root module changelog with repository changelog path": {
+			summary: releaseSummary{
+				ReleaseID:           "2021-05-05",
+				ChangelogPath:       "docs/RELEASES.md",
+				RootModuleChangelog: true,
+				Modules: map[string]moduleSummary{
+					".": {
+						ReleaseID:  "2021-05-05",
+						ModulePath: "a/b",
+						Version:    "v1.1.0",
+						Annotations: []changelog.Annotation{
+							{
+								Type:        changelog.FeatureChangeType,
+								Description: "b",
+							},
+						},
+					},
+					"c/d": {
+						ReleaseID:  "2021-05-05",
+						ModulePath: "a/b/c/d",
+						Version:    "v1.1.0",
+						Annotations: []changelog.Annotation{
+							{
+								Type:        changelog.FeatureChangeType,
+								Description: "a",
+							},
+						},
+					},
+				},
+			},
+			wantWr: `# Release (2021-05-05)
+
+## Module Highlights
+* ` + "`a/b`" + `: [v1.1.0](../CHANGELOG.md#v110-2021-05-05)
+  * **Feature**: b
+* ` + "`a/b/c/d`" + `: [v1.1.0](../c/d/CHANGELOG.md#v110-2021-05-05)
+  * **Feature**: a
+
 `,
 		},
 		"no release notes": {
@@ -519,3 +616,63 @@ func Test_generateSummary(t *testing.T) {
 		})
 	}
 }
+
+func Test_annotationReferences(t *testing.T) {
+	annotation := changelog.Annotation{
+		PullRequests: []int{12},
+		Issues:       []int{3},
+		Authors:      []string{"jdoe"},
+	}
+
+	tests := map[string]struct {
+		repositoryURL string
+		annotation    changelog.Annotation
+		want          string
+	}{
+		"no references": {
+			repositoryURL: "https://github.com/aws/aws-sdk-go-v2",
+		},
+		"no repository url": {
+			annotation: annotation,
+			want:       " (#12, #3, @jdoe)",
+		},
+		"repository url": {
+			repositoryURL: "https://github.com/aws/aws-sdk-go-v2/",
+			annotation:    annotation,
+			want: " ([#12](https://github.com/aws/aws-sdk-go-v2/pull/12), " +
+				"[#3](https://github.com/aws/aws-sdk-go-v2/issues/3), [@jdoe](https://github.com/jdoe))",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, annotationReferences(tt.repositoryURL, tt.annotation)); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_executeModuleTemplate_References(t *testing.T) {
+	summary := moduleSummary{
+		ReleaseID:     "2021-05-05",
+		Version:       "v1.0.1",
+		RepositoryURL: "https://github.com/aws/aws-sdk-go-v2",
+		Annotations: []changelog.Annotation{
+			{Type: changelog.BugFixChangeType, Description: "Fixed a broken thing.", PullRequests: []int{12}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := executeModuleTemplate(&buf, summary); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	want := `# v1.0.1 (2021-05-05)
+
+* **Bug Fix**: Fixed a broken thing. ([#12](https://github.com/aws/aws-sdk-go-v2/pull/12))
+
+`
+	if diff := cmp.Diff(want, buf.String()); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
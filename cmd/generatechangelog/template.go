@@ -2,35 +2,65 @@ package main
 
 import (
 	"fmt"
-	"strings"
+	"io/ioutil"
+	"path/filepath"
 	"text/template"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
 )
 
-var repoChangeLogTemplate = template.Must(template.New("repoChangeLog").
-	Funcs(map[string]interface{}{
-		"inlineCodeBlock": inlineCodeBlock,
-		"modulesForHighlight": func(v map[string]moduleSummary) (f map[string]moduleSummary) {
-			f = make(map[string]moduleSummary)
-			for modDir, summary := range v {
-				for i := range summary.Annotations {
-					if !summary.Annotations[i].Collapse {
-						f[modDir] = summary
-					}
-				}
-			}
-			return f
-		},
-		"changeLogLink": func(relModDir, version string, releaseID string) string {
-			if relModDir == "." {
-				return version
+// templateFuncs are the helper functions available to both the built-in and user-supplied changelog templates.
+var templateFuncs = map[string]interface{}{
+	"inlineCodeBlock": inlineCodeBlock,
+	"modulesForHighlight": func(v map[string]moduleSummary) map[string]moduleSummary {
+		return filterModules(v, func(a changelog.Annotation) bool {
+			return !a.Collapse && !a.Type.IsNotice()
+		})
+	},
+	"modulesForNotice": func(v map[string]moduleSummary) map[string]moduleSummary {
+		return filterModules(v, func(a changelog.Annotation) bool {
+			return !a.Collapse && a.Type.IsNotice()
+		})
+	},
+	"notices": func(v []changelog.Annotation) []changelog.Annotation {
+		return filterAnnotations(v, func(a changelog.Annotation) bool {
+			return a.Type.IsNotice()
+		})
+	},
+	"withoutNotices": func(v []changelog.Annotation) []changelog.Annotation {
+		return filterAnnotations(v, func(a changelog.Annotation) bool {
+			return !a.Type.IsNotice()
+		})
+	},
+}
+
+// filterModules returns the modules that have at least one annotation matching the filter.
+func filterModules(v map[string]moduleSummary, filter func(changelog.Annotation) bool) map[string]moduleSummary {
+	f := make(map[string]moduleSummary)
+	for modDir, summary := range v {
+		for i := range summary.Annotations {
+			if filter(summary.Annotations[i]) {
+				f[modDir] = summary
+				break
 			}
+		}
+	}
+	return f
+}
 
-			lv := strings.ReplaceAll(version, ".", "")
-			lr := strings.ReplaceAll(releaseID, ".", "")
+// filterAnnotations returns the annotations matching the filter.
+func filterAnnotations(v []changelog.Annotation, filter func(changelog.Annotation) bool) (f []changelog.Annotation) {
+	for _, a := range v {
+		if filter(a) {
+			f = append(f, a)
+		}
+	}
+	return f
+}
 
-			return fmt.Sprintf("[%s](%s/CHANGELOG.md#%s)", version, relModDir, strings.ToLower(lv+"-"+lr))
-		},
-	}).
+var repoChangeLogTemplate = template.Must(template.New("repoChangeLog").
+	Funcs(templateFuncs).
 	Parse(`{{ define "entry" -}}
 # Release ({{ .ReleaseID }})
 
@@ -38,19 +68,35 @@ var repoChangeLogTemplate = template.Must(template.New("repoChangeLog").
 {{ end }}{{/* template */}}
 {{ define "summary" -}}
 {{ if (not .IsEmptyReleaseSummary) -}}
-{{ if (gt (len .General) 0) -}}
+{{ $gn := (notices .General) -}}
+{{ $mn := (modulesForNotice .Modules) -}}
+{{ if (or (gt (len $gn) 0) (gt (len $mn) 0)) -}}
+## Security and Deprecation Notices
+{{ range $_, $a := $gn -}}
+* **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}{{ $.References $a }}
+{{ end -}}{{/* range */ -}}
+{{ range $name, $mod := $mn -}}
+* {{ inlineCodeBlock $mod.ModulePath }}: {{ $.ModuleChangelogLink $name $mod }}
+{{ range $_, $a := (notices $mod.Annotations) -}}
+{{- if (not $a.Collapse) }}  * **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}{{ $.References $a }}
+{{ end -}}{{/* if */ -}}
+{{ end -}}{{/* range */ -}}
+{{ end }}{{/* range */}}
+{{ end -}}{{/* if */ -}}
+{{ $gh := (withoutNotices .General) -}}
+{{ if (gt (len $gh) 0) -}}
 ## General Highlights
-{{ range $_, $a := .General -}}
-* **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}
+{{ range $_, $a := $gh -}}
+* **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}{{ $.References $a }}
 {{ end }}{{/* range */}}
 {{ end -}}{{/* if */ -}}
 {{ $mh := (modulesForHighlight .Modules) -}}
 {{ if (gt (len $mh) 0) -}}
 ## Module Highlights
 {{ range $name, $mod := $mh -}}
-* {{ inlineCodeBlock $mod.ModulePath }}: {{ changeLogLink $name $mod.Version $mod.ReleaseID }}
-{{ range $_, $a := $mod.Annotations -}}
-{{- if (not $a.Collapse) }}  * **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}
+* {{ inlineCodeBlock $mod.ModulePath }}: {{ $.ModuleChangelogLink $name $mod }}
+{{ range $_, $a := (withoutNotices $mod.Annotations) -}}
+{{- if (not $a.Collapse) }}  * **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}{{ $.References $a }}
 {{ end -}}{{/* if */ -}}
 {{ end -}}{{/* range */ -}}
 {{ end }}{{/* range */}}
@@ -63,16 +109,61 @@ var repoChangeLogTemplate = template.Must(template.New("repoChangeLog").
 `))
 
 var moduleChangeLogTemplate = template.Must(template.New("moduleChangeLog").
-	Funcs(map[string]interface{}{
-		"inlineCodeBlock": inlineCodeBlock,
-	}).
+	Funcs(templateFuncs).
 	Parse(`# {{ .Version }} ({{ .ReleaseID }})
 
 {{ if (len .Annotations) -}}
 {{- range $_, $a := .Annotations -}}
-* **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}
+* **{{ $a.Type.ChangelogPrefix }}**: {{ $a.Description }}{{ $.References $a }}
 {{ end -}}
 {{- else -}}
 * No change notes available for this release.
 {{ end }}
 `))
+
+// parseRepoChangeLogTemplate parses the user-supplied repository changelog template text on top of the built-in
+// repository template. The text may redefine the "entry" and "summary" templates, any template not redefined keeps
+// its built-in definition.
+func parseRepoChangeLogTemplate(text string) (*template.Template, error) {
+	t, err := repoChangeLogTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return t.Parse(text)
+}
+
+// parseModuleChangeLogTemplate parses the user-supplied module changelog template text, replacing the built-in
+// module template.
+func parseModuleChangeLogTemplate(text string) (*template.Template, error) {
+	return template.New("moduleChangeLog").Funcs(templateFuncs).Parse(text)
+}
+
+// loadTemplates replaces the built-in changelog templates with the user-supplied template files configured for the
+// repository.
+func loadTemplates(repoRoot string, config repotools.ChangelogConfig) error {
+	if len(config.RepositoryTemplate) > 0 {
+		text, err := ioutil.ReadFile(filepath.Join(repoRoot, filepath.FromSlash(config.RepositoryTemplate)))
+		if err != nil {
+			return fmt.Errorf("failed to read repository changelog template, %w", err)
+		}
+		t, err := parseRepoChangeLogTemplate(string(text))
+		if err != nil {
+			return fmt.Errorf("failed to parse repository changelog template %v, %w", config.RepositoryTemplate, err)
+		}
+		repoChangeLogTemplate = t
+	}
+
+	if len(config.ModuleTemplate) > 0 {
+		text, err := ioutil.ReadFile(filepath.Join(repoRoot, filepath.FromSlash(config.ModuleTemplate)))
+		if err != nil {
+			return fmt.Errorf("failed to read module changelog template, %w", err)
+		}
+		t, err := parseModuleChangeLogTemplate(string(text))
+		if err != nil {
+			return fmt.Errorf("failed to parse module changelog template %v, %w", config.ModuleTemplate, err)
+		}
+		moduleChangeLogTemplate = t
+	}
+
+	return nil
+}
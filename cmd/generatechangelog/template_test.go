@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_loadTemplates(t *testing.T) {
+	origRepo, origModule := repoChangeLogTemplate, moduleChangeLogTemplate
+	t.Cleanup(func() {
+		repoChangeLogTemplate, moduleChangeLogTemplate = origRepo, origModule
+	})
+
+	repoRoot := t.TempDir()
+	templates := map[string]string{
+		"templates/repo.tmpl": `{{ define "summary" -}}
+{{ range $name, $mod := (modulesForHighlight .Modules) -}}
+- {{ inlineCodeBlock $mod.ModulePath }} {{ $.ModuleChangelogLink $name $mod }}
+{{ end -}}
+{{ end -}}
+`,
+		"templates/module.tmpl": `## {{ .Version }}
+{{ range $_, $a := .Annotations -}}
+- {{ $a.Description }}
+{{ end -}}
+`,
+		"templates/invalid.tmpl": `{{ define "summary" }}`,
+	}
+	for name, text := range templates {
+		p := filepath.Join(repoRoot, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, config := range []repotools.ChangelogConfig{
+		{RepositoryTemplate: "templates/invalid.tmpl"},
+		{ModuleTemplate: "templates/missing.tmpl"},
+	} {
+		if err := loadTemplates(repoRoot, config); err == nil {
+			t.Errorf("expect error for %v, got none", config)
+		}
+	}
+
+	if err := loadTemplates(repoRoot, repotools.ChangelogConfig{
+		RepositoryTemplate: "templates/repo.tmpl",
+		ModuleTemplate:     "templates/module.tmpl",
+	}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	module := moduleSummary{
+		ReleaseID:  "2021-05-05",
+		ModulePath: "a/b/c/d",
+		Version:    "v1.1.0",
+		Annotations: []changelog.Annotation{
+			{
+				Type:        changelog.FeatureChangeType,
+				Description: "a",
+			},
+		},
+	}
+	summary := releaseSummary{
+		ReleaseID: "2021-05-05",
+		Modules: map[string]moduleSummary{
+			"c/d": module,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := executeRepoChangeLogEntryTemplate(&buf, summary); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expectEntry := "# Release (2021-05-05)\n\n- `a/b/c/d` [v1.1.0](c/d/CHANGELOG.md#v110-2021-05-05)\n"
+	if diff := cmp.Diff(expectEntry, buf.String()); len(diff) > 0 {
+		t.Error(diff)
+	}
+
+	buf.Reset()
+	if err := executeModuleTemplate(&buf, module); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if diff := cmp.Diff("## v1.1.0\n- a\n", buf.String()); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -15,7 +16,27 @@ import (
 	"golang.org/x/mod/modfile"
 )
 
+var workFile bool
+var undo bool
+var check bool
+
+func init() {
+	flag.BoolVar(&workFile, "work", false, "write a go.work file for the modules instead of go.mod replace directives")
+	flag.BoolVar(&undo, "undo", false, "remove the go.mod replace directives for modules in the repository")
+	flag.BoolVar(&check, "check", false, "fail if any go.mod has replace directives for modules in the repository")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "%s [-work | -undo | -check] [module...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+}
+
 func main() {
+	flag.Parse()
+
+	if countTrue(workFile, undo, check) > 1 {
+		log.Fatalf("only one of -work, -undo, or -check can be specified")
+	}
+
 	repoRoot, err := repotools.GetRepoRoot()
 	if err != nil {
 		log.Fatalf("failed to get repository root: %v", err)
@@ -42,6 +63,45 @@ func main() {
 		modules = append(modules, m.Module.Mod.Path)
 	}
 
+	// Scope to the named modules and the modules they transitively require
+	if flag.NArg() > 0 {
+		selected, err := selectModules(moduleTree, registry, flag.Args())
+		if err != nil {
+			log.Fatal(err)
+		}
+		modules, err = registry.Closure(repoRoot, selected...)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if workFile {
+		if err := writeWorkFile(repoRoot, registry, modules); err != nil {
+			log.Fatalf("failed to write %v: %v", workFileName, err)
+		}
+		return
+	}
+
+	if check {
+		if found := checkRelativeReplaces(repoRoot, registry, modules); found {
+			log.Fatalf("go.mod replace directives for modules in the repository must be removed, (makerelative -undo)")
+		}
+		return
+	}
+
+	if undo {
+		for _, modulePath := range modules {
+			if err := removeRelativeReplaces(modulePath, registry); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		if err := writeModules(repoRoot, registry); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var modulePath string
 	for len(modules) > 0 {
 		modulePath, modules = modules[0], modules[1:]
@@ -105,6 +165,31 @@ func (r *Registry) Get(path string) (string, *Module, error) {
 	return dir, module, nil
 }
 
+// Closure returns the sorted module paths of the given modules and their transitive dependencies in the registry.
+// The registered module directories must be within the repository root.
+func (r *Registry) Closure(repoRoot string, modulePaths ...string) ([]string, error) {
+	files := make(map[string]*modfile.File, len(r.dirToModule))
+	for dir, module := range r.dirToModule {
+		relDir, err := filepath.Rel(repoRoot, dir)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.ToSlash(relDir)] = module.File
+	}
+
+	graph, err := gomod.NewModuleGraph(files)
+	if err != nil {
+		return nil, err
+	}
+
+	closure, err := graph.Subgraph(modulePaths, gomod.DependenciesDirection, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return closure.Modules(), nil
+}
+
 // Has returns whether the given module path is in the registry.
 func (r *Registry) Has(path string) bool {
 	_, ok := r.modulePathToDir[path]
@@ -169,12 +254,41 @@ func (m *Module) Write() error {
 	return nil
 }
 
+// Modified returns whether the go.mod has pending changes.
+func (m *Module) Modified() bool {
+	return m.modified
+}
+
+// DropReplace deletes the replacement of oldPath and oldVers.
+func (m *Module) DropReplace(oldPath, oldVers string) error {
+	m.modified = true
+	return m.File.DropReplace(oldPath, oldVers)
+}
+
 // AddReplace replaces oldPath with newPath.
 func (m *Module) AddReplace(oldPath, oldVers, newPath, newVers string) error {
 	m.modified = true
 	return m.File.AddReplace(oldPath, oldVers, newPath, newVers)
 }
 
+// selectModules returns the module paths of the given module directories relative to the repository root.
+func selectModules(moduleTree *gomod.ModuleTree, registry *Registry, relDirs []string) (modulePaths []string, err error) {
+	for _, relDir := range relDirs {
+		node := moduleTree.Get(filepath.ToSlash(filepath.Clean(relDir)))
+		if node == nil {
+			return nil, fmt.Errorf("module not found: %v", relDir)
+		}
+
+		module, err := registry.Load(node.AbsPath())
+		if err != nil {
+			return nil, err
+		}
+		modulePaths = append(modulePaths, module.Module.Mod.Path)
+	}
+
+	return modulePaths, nil
+}
+
 type toReplace struct {
 	ModulePath   string
 	RelativePath string
@@ -256,3 +370,89 @@ func addRelativeReplaces(repoRoot, modulePath string, registry *Registry) error
 
 	return nil
 }
+
+// relativeReplaces returns the replace directives of the module that replace modules in the registry.
+func relativeReplaces(mod *Module, registry *Registry) (replaces []*modfile.Replace) {
+	for _, replace := range mod.Replace {
+		if registry.Has(replace.Old.Path) {
+			replaces = append(replaces, replace)
+		}
+	}
+	return replaces
+}
+
+// removeRelativeReplaces removes the go.mod replace directives of the given module that replace modules in the
+// registry. Replace directives for modules outside the repository are kept.
+func removeRelativeReplaces(modulePath string, registry *Registry) error {
+	_, mod := registry.MustGet(modulePath)
+
+	for _, replace := range relativeReplaces(mod, registry) {
+		if err := mod.DropReplace(replace.Old.Path, replace.Old.Version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRelativeReplaces logs the go.mod of each of the given modules with replace directives for modules in the
+// registry, returning whether any were found.
+func checkRelativeReplaces(repoRoot string, registry *Registry, modulePaths []string) (found bool) {
+	sort.Strings(modulePaths)
+
+	for _, modulePath := range modulePaths {
+		_, mod := registry.MustGet(modulePath)
+		replaces := relativeReplaces(mod, registry)
+		if len(replaces) == 0 {
+			continue
+		}
+		found = true
+
+		for _, replace := range replaces {
+			log.Printf("%v: replace %v => %v", relativeModFile(repoRoot, mod), replace.Old.Path, replace.New.Path)
+		}
+	}
+	return found
+}
+
+// writeModules writes the pending changes of each module in the registry, printing the path of each go.mod that was
+// changed relative to the repository root.
+func writeModules(repoRoot string, registry *Registry) error {
+	for _, mod := range sortedModules(registry) {
+		if !mod.Modified() {
+			continue
+		}
+		if err := mod.Write(); err != nil {
+			return err
+		}
+		fmt.Println(relativeModFile(repoRoot, mod))
+	}
+	return nil
+}
+
+// sortedModules returns the modules of the registry sorted by their go.mod file path.
+func sortedModules(registry *Registry) []*Module {
+	modules := registry.Modules()
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Syntax.Name < modules[j].Syntax.Name
+	})
+	return modules
+}
+
+// relativeModFile returns the slash separated path of the module's go.mod relative to the repository root.
+func relativeModFile(repoRoot string, mod *Module) string {
+	rel, err := filepath.Rel(repoRoot, mod.Syntax.Name)
+	if err != nil {
+		return mod.Syntax.Name
+	}
+	return filepath.ToSlash(rel)
+}
+
+func countTrue(values ...bool) (n int) {
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_removeRelativeReplaces(t *testing.T) {
+	repoRoot, registry := writeModuleFiles(t, map[string]string{
+		"a/go.mod": "module example.com/m/a\n\ngo 1.16\n\nrequire example.com/m/b v1.0.0\n\nreplace example.com/ext => ../../ext\n\nreplace example.com/m/b => ../b/\n",
+		"b/go.mod": "module example.com/m/b\n\ngo 1.16\n",
+	})
+
+	if found := checkRelativeReplaces(repoRoot, registry, []string{"example.com/m/a", "example.com/m/b"}); !found {
+		t.Errorf("expect relative replaces to be found")
+	}
+
+	for _, modulePath := range []string{"example.com/m/a", "example.com/m/b"} {
+		if err := removeRelativeReplaces(modulePath, registry); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	if _, mod := registry.MustGet("example.com/m/b"); mod.Modified() {
+		t.Errorf("expect module without relative replaces to not be modified")
+	}
+
+	if err := writeModules(repoRoot, registry); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if found := checkRelativeReplaces(repoRoot, registry, []string{"example.com/m/a", "example.com/m/b"}); found {
+		t.Errorf("expect no relative replaces to be found")
+	}
+
+	actual, err := ioutil.ReadFile(filepath.Join(repoRoot, "a", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := "module example.com/m/a\n\ngo 1.16\n\nrequire example.com/m/b v1.0.0\n\nreplace example.com/ext => ../../ext\n"
+	if diff := cmp.Diff(expect, string(actual)); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
+
+func TestRegistry_Closure(t *testing.T) {
+	repoRoot, registry := writeModuleFiles(t, map[string]string{
+		"a/go.mod": "module example.com/m/a\n\ngo 1.16\n\nrequire example.com/m/b v1.0.0\n",
+		"b/go.mod": "module example.com/m/b\n\ngo 1.16\n\nrequire (\n\texample.com/m/c v1.0.0\n\texample.com/ext v1.0.0\n)\n",
+		"c/go.mod": "module example.com/m/c\n\ngo 1.16\n",
+		"d/go.mod": "module example.com/m/d\n\ngo 1.16\n\nrequire example.com/m/a v1.0.0\n",
+	})
+
+	closure, err := registry.Closure(repoRoot, "example.com/m/a")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := []string{"example.com/m/a", "example.com/m/b", "example.com/m/c"}
+	if diff := cmp.Diff(expect, closure); len(diff) > 0 {
+		t.Fatal(diff)
+	}
+
+	for _, modulePath := range closure {
+		if err := addRelativeReplaces(repoRoot, modulePath, registry); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	modified := map[string]bool{}
+	for _, modulePath := range []string{"example.com/m/a", "example.com/m/b", "example.com/m/c", "example.com/m/d"} {
+		_, mod := registry.MustGet(modulePath)
+		modified[modulePath] = mod.Modified()
+	}
+
+	expectModified := map[string]bool{
+		"example.com/m/a": true,
+		"example.com/m/b": true,
+		"example.com/m/c": false,
+		"example.com/m/d": false,
+	}
+	if diff := cmp.Diff(expectModified, modified); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
+
+// writeModuleFiles writes the files, keyed by slash separated path, to a temporary repository root, returning the
+// root and a registry with each module of the files loaded.
+func writeModuleFiles(t *testing.T, files map[string]string) (string, *Registry) {
+	t.Helper()
+
+	repoRoot := t.TempDir()
+	registry := NewRegistry()
+
+	for name, content := range files {
+		path := filepath.Join(repoRoot, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name := range files {
+		if name := filepath.FromSlash(name); filepath.Base(name) == "go.mod" {
+			registry.MustLoad(filepath.Join(repoRoot, filepath.Dir(name)))
+		}
+	}
+
+	return repoRoot, registry
+}
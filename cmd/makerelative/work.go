@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/internal/semver"
+	"golang.org/x/mod/modfile"
+)
+
+const workFileName = "go.work"
+
+// minimumWorkGoVersion is the earliest Go version that supports go.work files.
+const minimumWorkGoVersion = "1.18"
+
+// writeWorkFile writes the go.work file at the repository root, using the directories of the given modules. If a
+// go.work file already exists its go version is never lowered, and any directives other than go and use, (e.g.
+// replace), and use directives for directories outside the repository are preserved.
+func writeWorkFile(repoRoot string, registry *Registry, modulePaths []string) error {
+	workPath := filepath.Join(repoRoot, workFileName)
+
+	var existing *modfile.File
+	data, err := ioutil.ReadFile(workPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	} else if err == nil {
+		existing, err = modfile.ParseLax(workPath, data, nil)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v: %w", workPath, err)
+		}
+	}
+
+	useDirs := make([]string, 0, len(modulePaths))
+	goVersion := minimumWorkGoVersion
+	for _, modulePath := range modulePaths {
+		modDir, mod := registry.MustGet(modulePath)
+
+		relDir, err := filepath.Rel(repoRoot, modDir)
+		if err != nil {
+			return err
+		}
+		useDirs = append(useDirs, workUsePath(relDir))
+
+		if mod.Go != nil {
+			goVersion = maxGoVersion(goVersion, mod.Go.Version)
+		}
+	}
+	sort.Strings(useDirs)
+
+	syntax := &modfile.FileSyntax{Name: workPath}
+	goLine := &modfile.Line{}
+	use := &modfile.LineBlock{Token: []string{"use"}}
+	var external []*modfile.Line
+	if existing != nil {
+		syntax.Comments = existing.Syntax.Comments
+		if existing.Go != nil {
+			goVersion = maxGoVersion(goVersion, existing.Go.Version)
+			goLine.Comments = existing.Go.Syntax.Comments
+		}
+		for _, stmt := range existing.Syntax.Stmt {
+			switch stmt := stmt.(type) {
+			case *modfile.Line:
+				if len(stmt.Token) > 0 && stmt.Token[0] == "go" {
+					continue
+				}
+				if len(stmt.Token) > 1 && stmt.Token[0] == "use" {
+					if isExternalUse(repoRoot, stmt.Token[1]) {
+						external = append(external, &modfile.Line{Comments: stmt.Comments, Token: stmt.Token[1:], InBlock: true})
+					}
+					continue
+				}
+			case *modfile.LineBlock:
+				if len(stmt.Token) > 0 && stmt.Token[0] == "use" {
+					use.Comments = stmt.Comments
+					for _, line := range stmt.Line {
+						if len(line.Token) > 0 && isExternalUse(repoRoot, line.Token[0]) {
+							external = append(external, line)
+						}
+					}
+					continue
+				}
+			}
+			syntax.Stmt = append(syntax.Stmt, stmt)
+		}
+	}
+	goLine.Token = []string{"go", goVersion}
+
+	for _, dir := range useDirs {
+		use.Line = append(use.Line, &modfile.Line{Token: []string{modfile.AutoQuote(dir)}, InBlock: true})
+	}
+	use.Line = append(use.Line, external...)
+
+	syntax.Stmt = append([]modfile.Expr{goLine, use}, syntax.Stmt...)
+
+	formatted := modfile.Format(syntax)
+	if bytes.Equal(formatted, data) {
+		return nil
+	}
+
+	return ioutil.WriteFile(workPath, formatted, 0644)
+}
+
+// workUsePath returns the go.work use directive path for the module directory relative to the repository root.
+func workUsePath(relDir string) string {
+	relDir = filepath.ToSlash(relDir)
+	if relDir == "." {
+		return relDir
+	}
+	return "./" + relDir
+}
+
+// isExternalUse returns whether the use directive path refers to a directory outside of the repository. Directories
+// within the repository are always regenerated from the discovered modules.
+func isExternalUse(repoRoot, usePath string) bool {
+	dir := filepath.FromSlash(strings.Trim(usePath, "\"`"))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoRoot, dir)
+	}
+
+	rel, err := filepath.Rel(repoRoot, dir)
+	if err != nil {
+		return true
+	}
+
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// maxGoVersion returns the higher of the two Go versions.
+func maxGoVersion(a, b string) string {
+	if semver.Compare("v"+a, "v"+b) < 0 {
+		return b
+	}
+	return a
+}
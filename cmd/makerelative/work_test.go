@@ -0,0 +1,48 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_writeWorkFile(t *testing.T) {
+	repoRoot, registry := writeModuleFiles(t, map[string]string{
+		"go.mod":   "module example.com/m\n\ngo 1.16\n",
+		"a/go.mod": "module example.com/m/a\n\ngo 1.20\n\nrequire example.com/m/b v1.0.0\n",
+		"b/go.mod": "module example.com/m/b\n\ngo 1.19\n",
+		"c/go.mod": "module example.com/m/c\n\ngo 1.16\n",
+		"go.work":  "// comment\ngo 1.18\n\nuse (\n\t./c\n\t../other // external\n)\n\nreplace example.com/x => ../x\n",
+	})
+
+	closure, err := registry.Closure(repoRoot, "example.com/m/a")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if err := writeWorkFile(repoRoot, registry, closure); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	actual, err := ioutil.ReadFile(filepath.Join(repoRoot, "go.work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `// comment
+go 1.20
+
+use (
+	./a
+	./b
+	../other // external
+)
+
+replace example.com/x => ../x
+`
+	if diff := cmp.Diff(expect, string(actual)); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
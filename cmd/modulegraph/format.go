@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+)
+
+// graphFormat is the output format of the module dependency graph.
+type graphFormat string
+
+const (
+	dotGraphFormat     graphFormat = "dot"
+	jsonGraphFormat    graphFormat = "json"
+	mermaidGraphFormat graphFormat = "mermaid"
+)
+
+// Set validates and sets the graph format, satisfying flag.Value.
+func (f *graphFormat) Set(v string) error {
+	switch gf := graphFormat(strings.ToLower(v)); gf {
+	case dotGraphFormat, jsonGraphFormat, mermaidGraphFormat:
+		*f = gf
+		return nil
+	default:
+		return fmt.Errorf("unknown graph format %q, expect dot, json, or mermaid", v)
+	}
+}
+
+// String returns the graph format, satisfying flag.Value.
+func (f *graphFormat) String() string {
+	return string(*f)
+}
+
+// writeGraph writes the module dependency graph in the given format. Modules are identified by their relative
+// repository path.
+func writeGraph(w io.Writer, format graphFormat, graph *gomod.ModuleGraph) error {
+	switch format {
+	case jsonGraphFormat:
+		return writeJSONGraph(w, graph)
+	case mermaidGraphFormat:
+		return writeMermaidGraph(w, graph)
+	default:
+		return writeDOTGraph(w, graph)
+	}
+}
+
+// moduleJSON is a module of the JSON module dependency graph.
+type moduleJSON struct {
+	ModuleDir  string   `json:"module_dir"`
+	ModulePath string   `json:"module_path"`
+	Requires   []string `json:"requires"`
+}
+
+func writeJSONGraph(w io.Writer, graph *gomod.ModuleGraph) error {
+	modules := make([]moduleJSON, 0)
+	for _, modulePath := range graph.Modules() {
+		node, _ := graph.Module(modulePath)
+		modules = append(modules, moduleJSON{
+			ModuleDir:  node.RelativeRepoPath,
+			ModulePath: node.Path,
+			Requires:   requiredModuleDirs(graph, node),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(struct {
+		Modules []moduleJSON `json:"modules"`
+	}{Modules: modules})
+}
+
+func writeDOTGraph(w io.Writer, graph *gomod.ModuleGraph) error {
+	var sb strings.Builder
+	sb.WriteString("digraph modules {\n")
+	for _, modulePath := range graph.Modules() {
+		node, _ := graph.Module(modulePath)
+		requires := requiredModuleDirs(graph, node)
+		if len(requires) == 0 {
+			fmt.Fprintf(&sb, "\t%s;\n", strconv.Quote(node.RelativeRepoPath))
+			continue
+		}
+		for _, require := range requires {
+			fmt.Fprintf(&sb, "\t%s -> %s;\n", strconv.Quote(node.RelativeRepoPath), strconv.Quote(require))
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMermaidGraph(w io.Writer, graph *gomod.ModuleGraph) error {
+	modules := graph.Modules()
+
+	// Mermaid node ids can not contain path separators, so each module is assigned an id by its sorted index.
+	ids := make(map[string]string, len(modules))
+	for i, modulePath := range modules {
+		ids[modulePath] = "m" + strconv.Itoa(i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for _, modulePath := range modules {
+		node, _ := graph.Module(modulePath)
+		fmt.Fprintf(&sb, "\t%s[\"%s\"]\n", ids[modulePath], node.RelativeRepoPath)
+	}
+	for _, modulePath := range modules {
+		node, _ := graph.Module(modulePath)
+		for _, require := range node.Requires {
+			fmt.Fprintf(&sb, "\t%s --> %s\n", ids[modulePath], ids[require])
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// requiredModuleDirs returns the relative repository paths of the modules required by the node.
+func requiredModuleDirs(graph *gomod.ModuleGraph, node *gomod.ModuleGraphNode) []string {
+	dirs := make([]string, 0, len(node.Requires))
+	for _, modulePath := range node.Requires {
+		require, _ := graph.Module(modulePath)
+		dirs = append(dirs, require.RelativeRepoPath)
+	}
+	return dirs
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/mod/modfile"
+)
+
+func Test_writeGraph(t *testing.T) {
+	files := map[string]string{
+		".":         "module example.com/m\n",
+		"service/a": "module example.com/m/service/a\n\nrequire example.com/m v1.0.0\n",
+		"service/b": "module example.com/m/service/b\n\nrequire (\n\texample.com/m v1.0.0\n\texample.com/m/service/a v1.0.0\n)\n",
+	}
+	parsed := make(map[string]*modfile.File)
+	for relPath, content := range files {
+		file, err := modfile.Parse(relPath+"/go.mod", []byte(content), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed[relPath] = file
+	}
+	graph, err := gomod.NewModuleGraph(parsed)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	tests := map[string]struct {
+		format graphFormat
+		want   string
+	}{
+		"dot": {
+			format: dotGraphFormat,
+			want: `digraph modules {
+	".";
+	"service/a" -> ".";
+	"service/b" -> ".";
+	"service/b" -> "service/a";
+}
+`,
+		},
+		"mermaid": {
+			format: mermaidGraphFormat,
+			want: `graph LR
+	m0["."]
+	m1["service/a"]
+	m2["service/b"]
+	m1 --> m0
+	m2 --> m0
+	m2 --> m1
+`,
+		},
+		"json": {
+			format: jsonGraphFormat,
+			want: `{
+    "modules": [
+        {
+            "module_dir": ".",
+            "module_path": "example.com/m",
+            "requires": []
+        },
+        {
+            "module_dir": "service/a",
+            "module_path": "example.com/m/service/a",
+            "requires": [
+                "."
+            ]
+        },
+        {
+            "module_dir": "service/b",
+            "module_path": "example.com/m/service/b",
+            "requires": [
+                ".",
+                "service/a"
+            ]
+        }
+    ]
+}
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := bytes.NewBuffer(nil)
+			if err := writeGraph(wr, tt.format, graph); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.want, wr.String()); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_graphFormat_Set(t *testing.T) {
+	var f graphFormat
+	if err := f.Set("Mermaid"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := mermaidGraphFormat, f; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if err := f.Set("svg"); err == nil {
+		t.Error("expect error, got none")
+	}
+}
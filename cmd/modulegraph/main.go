@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+)
+
+var format = dotGraphFormat
+var dependents string
+var dependencies string
+var depth int
+var outputFile string
+var check bool
+
+func init() {
+	flag.Var(&format, "format", "the output format of the graph, dot, json, or mermaid")
+	flag.StringVar(&dependents, "dependents", "", "only graph the module directory and the modules that depend on it")
+	flag.StringVar(&dependencies, "dependencies", "", "only graph the module directory and the modules it depends on")
+	flag.IntVar(&depth, "depth", 0, "the maximum number of dependency edges from the -dependents or -dependencies module, 0 for no limit")
+	flag.StringVar(&outputFile, "o", "", "output file")
+	flag.BoolVar(&check, "check", false, "report the require cycles between modules instead of the graph, failing if any are found")
+}
+
+func main() {
+	flag.Parse()
+
+	if len(dependents) > 0 && len(dependencies) > 0 {
+		log.Fatalf("only one of -dependents or -dependencies can be specified")
+	}
+
+	repoRoot, err := repotools.GetRepoRoot()
+	if err != nil {
+		log.Fatalf("failed to get repository root: %v", err)
+	}
+
+	discoverer := gomod.NewDiscoverer(repoRoot)
+
+	if err := discoverer.Discover(); err != nil {
+		log.Fatalf("failed to discover repository modules: %v", err)
+	}
+
+	graph, err := gomod.LoadModuleGraph(discoverer.Modules())
+	if err != nil {
+		log.Fatalf("failed to load module graph: %v", err)
+	}
+
+	if relPath, direction := dependencies, gomod.DependenciesDirection; len(relPath) > 0 || len(dependents) > 0 {
+		if len(dependents) > 0 {
+			relPath, direction = dependents, gomod.DependentsDirection
+		}
+
+		node, ok := graph.ModuleByRelativeRepoPath(filepath.ToSlash(filepath.Clean(relPath)))
+		if !ok {
+			log.Fatalf("module not found: %v", relPath)
+		}
+
+		graph, err = graph.Subgraph([]string{node.Path}, direction, depth)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if check {
+		cycles := graph.Cycles()
+		for _, cycle := range cycles {
+			fmt.Fprintln(os.Stdout, cycle)
+		}
+		if len(cycles) > 0 {
+			log.Fatalf("found %d module dependency cycles", len(cycles))
+		}
+		return
+	}
+
+	var w io.Writer = os.Stdout
+	if len(outputFile) > 0 {
+		file, err := os.Create(outputFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			if err := file.Close(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+		w = file
+	}
+
+	if err := writeGraph(w, format, graph); err != nil {
+		log.Fatal(err)
+	}
+}
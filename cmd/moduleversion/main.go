@@ -3,9 +3,11 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
@@ -16,17 +18,20 @@ import (
 
 var (
 	getUnreleasedVersion bool
+	explain              bool
 	preview              preReleaseFlag
 )
 
 func init() {
 	flag.BoolVar(&getUnreleasedVersion, "unreleased", false,
 		"Returns the version the projected version the module will be at after the next release")
+	flag.BoolVar(&explain, "explain", false,
+		"Reports why the module is being released, including its changed files, carved out submodules, dependency updates, and annotations")
 	flag.Var(&preview, "preview",
 		"Indicates a semver pre-release should be calculated when specified with the -unreleased flag.")
 
 	flag.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), `Usage of %s [-unreleased] <module>
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage of %s [-unreleased | -explain] <module>
   module
 	The relative path of the module to get the version of.
 `, filepath.Base(os.Args[0]))
@@ -53,6 +58,10 @@ func main() {
 		log.Fatalf("failed to load repotools config: %v", err)
 	}
 
+	if err := changelog.RegisterChangeTypes(config.Changelog.ChangeTypes); err != nil {
+		log.Fatalf("failed to register change types: %v", err)
+	}
+
 	discoverer := gomod.NewDiscoverer(repoRoot)
 
 	if err := discoverer.Discover(); err != nil {
@@ -76,6 +85,15 @@ func main() {
 		log.Fatalf("failed to check repo modules, %v", err)
 	}
 
+	if explain {
+		explanation, ok := release.ExplainModule(checkedModules, moduleToCheck)
+		if !ok {
+			log.Fatalf("failed to find module, %v", moduleToCheck)
+		}
+		writeExplanation(os.Stdout, explanation)
+		return
+	}
+
 	if getUnreleasedVersion {
 		id := release.NextReleaseID(tags)
 		manifest, err := release.BuildReleaseManifest(discoverer.Modules(), id, checkedModules, false, preview.String())
@@ -84,7 +102,7 @@ func main() {
 		}
 
 		if m, ok := manifest.Modules[moduleToCheck]; ok {
-			moduleTag, err := git.ToModuleTag(moduleToCheck, m.To)
+			moduleTag, err := git.ToModuleTag(git.ModuleTagPath(moduleToCheck, m.ReleaseModulePath()), m.To)
 			if err != nil {
 				log.Fatalf("failed to get module %v tag, %v", moduleToCheck, err)
 			}
@@ -105,6 +123,57 @@ func main() {
 	fmt.Println(moduleVersion)
 }
 
+// writeExplanation writes a human readable report of why the module is being released.
+func writeExplanation(w io.Writer, e release.Explanation) {
+	fmt.Fprintf(w, "module %v (%v)\n", e.RelativeRepoPath, e.ModulePath)
+
+	latest := e.Latest
+	if len(latest) == 0 {
+		latest = "none"
+	}
+	fmt.Fprintf(w, "latest version: %v\n", latest)
+
+	if e.Changes == 0 {
+		fmt.Fprintln(w, "not scheduled for release, no changes since the latest version")
+		return
+	}
+	fmt.Fprintf(w, "changes: %v\n", e.Changes)
+
+	if e.Changes&release.NewModule != 0 {
+		fmt.Fprintln(w, "\nnew module, not previously tagged")
+	}
+
+	if len(e.FileChanges) > 0 {
+		fmt.Fprintln(w, "\nchanged files:")
+		for _, file := range e.FileChanges {
+			fmt.Fprintf(w, "  %v\n", file)
+		}
+	}
+
+	if len(e.CarvedOutModules) > 0 {
+		fmt.Fprintln(w, "\ncarved out submodules:")
+		for _, module := range e.CarvedOutModules {
+			fmt.Fprintf(w, "  %v\n", module)
+		}
+	}
+
+	if len(e.DependencyChain) > 0 {
+		fmt.Fprintln(w, "\ndependency update:")
+		fmt.Fprintf(w, "  %v -> %v\n", strings.Join(e.DependencyChain, " -> "), e.RelativeRepoPath)
+	}
+
+	fmt.Fprintf(w, "\nannotated version increment: %v\n", e.Increment)
+	for _, annotation := range e.DecidingAnnotations {
+		fmt.Fprintf(w, "  %v %v: %v\n", annotation.ID, annotation.Type, annotation.Description)
+	}
+	if len(e.OtherAnnotations) > 0 {
+		fmt.Fprintln(w, "\nother annotations:")
+		for _, annotation := range e.OtherAnnotations {
+			fmt.Fprintf(w, "  %v %v: %v\n", annotation.ID, annotation.Type, annotation.Description)
+		}
+	}
+}
+
 type preReleaseFlag string
 
 func (p *preReleaseFlag) String() string {
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/release"
+	"github.com/olekukonko/tablewriter"
+)
+
+var preview string
+var nativeGit bool
+
+func init() {
+	flag.StringVar(&preview, "preview", "", "indicates a semver pre-release should be calculated for all modules.")
+	flag.BoolVar(&nativeGit, "native-git", false, "read the git object database directly instead of invoking the git binary")
+}
+
+func main() {
+	flag.Parse()
+
+	repoRoot, err := repotools.GetRepoRoot()
+	if err != nil {
+		log.Fatalf("failed to get repository root: %v", err)
+	}
+
+	config, err := repotools.LoadConfig(repoRoot)
+	if err != nil {
+		log.Fatalf("failed to load repotools config: %v", err)
+	}
+
+	if err := changelog.RegisterChangeTypes(config.Changelog.ChangeTypes); err != nil {
+		log.Fatalf("failed to register change types: %v", err)
+	}
+
+	discoverer := gomod.NewDiscoverer(repoRoot)
+
+	if err := discoverer.Discover(); err != nil {
+		log.Fatalf("failed to discover repository modules: %v", err)
+	}
+
+	var repository git.Reader = git.NewExecRepository(repoRoot)
+	if nativeGit {
+		objectRepository, err := git.OpenRepository(repoRoot)
+		if err != nil {
+			log.Fatalf("failed to open git repository: %v", err)
+		}
+		defer objectRepository.Close()
+		repository = objectRepository
+	}
+
+	tags, err := repository.Tags()
+	if err != nil {
+		log.Fatalf("failed to get git tags: %v", err)
+	}
+
+	annotations, err := changelog.GetAnnotations(repoRoot)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	modules, err := release.Calculate(discoverer, git.ParseModuleTags(tags), config, annotations, func(o *release.CalculateOptions) {
+		o.Repository = repository
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	manifest, err := release.BuildReleaseManifest(discoverer.Modules(), release.NextReleaseID(tags), modules, false, preview)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(manifest.Modules) == 0 {
+		log.Println("no modules pending release")
+		return
+	}
+
+	log.Printf("release %v", manifest.ID)
+	writePreview(os.Stdout, manifest, modules)
+}
+
+// writePreview writes a table of the modules pending release with their current and next versions, the reason for
+// the release, the annotations driving the version increment, and the dependency chain of dependency updates.
+func writePreview(w io.Writer, manifest release.Manifest, modules map[string]*release.Module) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Module", "Current", "Next", "Reason", "Annotations", "Dependency Chain"})
+	table.SetRowLine(true)
+
+	for _, moduleDir := range sortedModuleDirs(manifest) {
+		mm := manifest.Modules[moduleDir]
+
+		current := mm.From
+		if len(current) == 0 {
+			current = "N/A"
+		}
+
+		var annotations []string
+		var chain []string
+		if mod, ok := modules[mm.ModulePath]; ok {
+			moduleAnnotations := append([]changelog.Annotation(nil), mod.ChangeAnnotations...)
+			sort.SliceStable(moduleAnnotations, func(i, j int) bool {
+				return moduleAnnotations[i].Type.Precedence() > moduleAnnotations[j].Type.Precedence()
+			})
+			for _, annotation := range moduleAnnotations {
+				annotations = append(annotations, annotation.Type.String()+": "+annotation.Description)
+			}
+
+			for _, modulePath := range release.DependencyUpdateChain(modules, mm.ModulePath) {
+				if dep, ok := modules[modulePath]; ok {
+					modulePath = dep.RelativeRepoPath
+				}
+				chain = append(chain, modulePath)
+			}
+			if len(chain) > 0 {
+				chain = append(chain, moduleDir)
+			}
+		}
+
+		table.Append([]string{
+			moduleDir,
+			current,
+			mm.To,
+			mm.Changes.String(),
+			strings.Join(annotations, "\n"),
+			strings.Join(chain, " -> "),
+		})
+	}
+
+	table.Render()
+}
+
+func sortedModuleDirs(manifest release.Manifest) []string {
+	moduleDirs := make([]string, 0, len(manifest.Modules))
+	for moduleDir := range manifest.Modules {
+		moduleDirs = append(moduleDirs, moduleDir)
+	}
+	sort.Strings(moduleDirs)
+	return moduleDirs
+}
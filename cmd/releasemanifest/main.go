@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/release"
+)
+
+const diffHelpDoc = `releasemanifest diff [-json] <old manifest> <new manifest>
+`
+
+const mergeHelpDoc = `releasemanifest merge [-o <output file>] <manifest> <manifest> [<manifest> ...]
+`
+
+var diffFlags = struct {
+	JSON bool
+}{}
+
+var diffFlagSet = func() *flag.FlagSet {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.BoolVar(&diffFlags.JSON, "json", false, "output the differences as JSON")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), diffHelpDoc)
+		fs.PrintDefaults()
+	}
+	return fs
+}()
+
+var mergeFlags = struct {
+	OutputFile string
+}{}
+
+var mergeFlagSet = func() *flag.FlagSet {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	fs.StringVar(&mergeFlags.OutputFile, "o", "", "output file")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), mergeHelpDoc)
+		fs.PrintDefaults()
+	}
+	return fs
+}()
+
+func init() {
+	flag.Usage = printHelp
+}
+
+func main() {
+	flag.Parse()
+
+	arg := flag.Arg(0)
+
+	var err error
+	switch {
+	case strings.EqualFold(arg, diffFlagSet.Name()):
+		err = runDiffCommand(flag.Args()[1:])
+	case strings.EqualFold(arg, mergeFlagSet.Name()):
+		err = runMergeCommand(flag.Args()[1:])
+	default:
+		printHelp()
+		return
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printHelp() {
+	var builder strings.Builder
+	builder.WriteString("Usage:\n\n")
+	builder.WriteString(diffHelpDoc)
+	builder.WriteRune('\n')
+	builder.WriteString(mergeHelpDoc)
+	fmt.Fprint(os.Stderr, builder.String())
+	os.Exit(0)
+}
+
+func runDiffCommand(args []string) error {
+	if err := diffFlagSet.Parse(args); err != nil {
+		return err
+	}
+
+	args = diffFlagSet.Args()
+	if len(args) != 2 {
+		return fmt.Errorf("expect two release manifest files to be provided")
+	}
+
+	oldManifest, err := release.LoadManifest(args[0])
+	if err != nil {
+		return err
+	}
+	newManifest, err := release.LoadManifest(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff := release.DiffManifests(oldManifest, newManifest)
+
+	if diffFlags.JSON {
+		marshal, err := json.MarshalIndent(diff, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(os.Stdout, "%s\n", marshal)
+		return err
+	}
+
+	return writeDiff(os.Stdout, oldManifest, newManifest, diff)
+}
+
+func writeDiff(w io.Writer, oldManifest, newManifest release.Manifest, diff release.ManifestDiff) error {
+	if diff.IsEmpty() {
+		_, err := fmt.Fprintln(w, "No module differences")
+		return err
+	}
+
+	var builder strings.Builder
+	for _, relPath := range diff.Added {
+		fmt.Fprintf(&builder, "+ %s %s\n", relPath, newManifest.Modules[relPath].To)
+	}
+	for _, relPath := range diff.Removed {
+		fmt.Fprintf(&builder, "- %s %s\n", relPath, oldManifest.Modules[relPath].To)
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(&builder, "~ %s", change.RelativeRepoPath)
+		if len(change.ToVersion) > 0 {
+			fmt.Fprintf(&builder, " %s => %s", change.FromVersion, change.ToVersion)
+		}
+		builder.WriteRune('\n')
+		for _, id := range change.AddedAnnotations {
+			fmt.Fprintf(&builder, "    + annotation %s\n", id)
+		}
+		for _, id := range change.RemovedAnnotations {
+			fmt.Fprintf(&builder, "    - annotation %s\n", id)
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func runMergeCommand(args []string) error {
+	if err := mergeFlagSet.Parse(args); err != nil {
+		return err
+	}
+
+	args = mergeFlagSet.Args()
+	if len(args) < 2 {
+		return fmt.Errorf("expect two or more release manifest files to be provided")
+	}
+
+	var manifests []release.Manifest
+	for _, arg := range args {
+		manifest, err := release.LoadManifest(arg)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	repoRoot, err := repotools.GetRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	tags, err := git.Tags(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get git tags: %w", err)
+	}
+
+	merged, err := release.MergeManifests(release.NextReleaseID(tags), manifests...)
+	if err != nil {
+		return err
+	}
+
+	if len(mergeFlags.OutputFile) == 0 {
+		return release.WriteManifest(os.Stdout, merged)
+	}
+
+	file, err := os.OpenFile(mergeFlags.OutputFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if err := release.WriteManifest(file, merged); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -1,10 +1,8 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 
 	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
@@ -13,9 +11,13 @@ import (
 )
 
 var releaseFile string
+var dryRun bool
+var transactional bool
 
 func init() {
 	flag.StringVar(&releaseFile, "release", "", "release manifest file path")
+	flag.BoolVar(&dryRun, "dry-run", false, "print the commit message and tags that would be created without modifying the repository")
+	flag.BoolVar(&transactional, "transactional", false, "delete any tags already created if creating a later tag fails")
 }
 
 func main() {
@@ -30,9 +32,9 @@ func main() {
 		log.Fatalf("failed to get repository root: %v", err)
 	}
 
-	manifest, err := loadManifest(releaseFile)
+	manifest, err := release.LoadManifest(releaseFile)
 	if err != nil {
-		log.Fatalf("failed to laod manifest: %v", err)
+		log.Fatalf("failed to load manifest: %v", err)
 	}
 
 	if len(manifest.Tags) == 0 {
@@ -40,39 +42,55 @@ func main() {
 		return
 	}
 
+	message := fmt.Sprintf("Release %s", manifest.ID)
+
+	tags := append([]string{}, manifest.Tags...)
+	if manifest.WithReleaseTag {
+		tags = append(tags, fmt.Sprintf("release-%s", manifest.ID))
+	}
+
+	if dryRun {
+		printPlan(message, tags)
+		return
+	}
+
 	if err = git.Add(repoRoot, "-A", "."); err != nil {
 		log.Fatalf("failed to add working directory changes: %v", err)
 	}
 
-	message := fmt.Sprintf("Release %s", manifest.ID)
-
 	if err = git.Commit(repoRoot, message); err != nil {
 		log.Fatalf("failed to add working directory changes: %v", err)
 	}
 
-	for _, tag := range manifest.Tags {
+	var created []string
+	for _, tag := range tags {
 		if err := git.Tag(repoRoot, tag, message, "HEAD"); err != nil {
+			if transactional {
+				rollbackTags(repoRoot, created)
+			}
 			log.Fatalf("failed to create tag %v: %v", tag, err)
 		}
-	}
-
-	if manifest.WithReleaseTag {
-		releaseTag := fmt.Sprintf("release-%s", manifest.ID)
-		if err = git.Tag(repoRoot, releaseTag, message, "HEAD"); err != nil {
-			log.Fatalf("failed to create release tag %v: %v", releaseTag, err)
-		}
+		created = append(created, tag)
 	}
 }
 
-func loadManifest(path string) (manifest release.Manifest, err error) {
-	fBytes, err := ioutil.ReadFile(path)
-	if err != nil {
-		return release.Manifest{}, err
+// printPlan prints the commit message and tags that would be created for the release.
+func printPlan(message string, tags []string) {
+	fmt.Printf("Commit: %s\n", message)
+	fmt.Println("Tags:")
+	for _, tag := range tags {
+		fmt.Printf("\t%s\n", tag)
 	}
+}
 
-	if err := json.Unmarshal(fBytes, &manifest); err != nil {
-		return release.Manifest{}, err
+// rollbackTags deletes the tags that were created before a failure occurred. Tags are deleted in the reverse order
+// they were created.
+func rollbackTags(repoRoot string, created []string) {
+	for i := len(created) - 1; i >= 0; i-- {
+		if err := git.DeleteTag(repoRoot, created[i]); err != nil {
+			log.Printf("[WARN] failed to delete tag %v: %v", created[i], err)
+			continue
+		}
+		log.Printf("[INFO] deleted tag %v", created[i])
 	}
-
-	return manifest, nil
 }
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"go/parser"
 	"go/token"
-	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
@@ -62,7 +60,7 @@ func main() {
 	moduleTags := git.ParseModuleTags(tags)
 
 	if len(releaseFileName) > 0 {
-		manifest, err := loadManifest(releaseFileName)
+		manifest, err := release.LoadManifest(releaseFileName)
 		if err != nil {
 			log.Fatalf("failed to load release manifest file: %v", err)
 		}
@@ -96,7 +94,15 @@ func main() {
 			log.Printf("[WARN] unable to determine go package for %v...skipping", module.Path())
 			continue
 		}
-		latest, isTagged := moduleTags.Latest(module.Path())
+		moduleFile, err := gomod.LoadModuleFile(module.AbsPath(), nil, true)
+		if err != nil {
+			log.Fatalf("failed to load module file: %v", err)
+		}
+		modulePath, err := gomod.GetModulePath(moduleFile)
+		if err != nil {
+			log.Fatalf("failed to read module path: %v", err)
+		}
+		latest, isTagged := moduleTags.Latest(git.ModuleTagPath(module.Path(), modulePath))
 
 		if cfg, ok := config.Modules[module.Path()]; (ok && cfg.NoTag) || !isTagged {
 			latest = "tip"
@@ -188,16 +194,3 @@ func writeModuleMetadata(dir string, goPackage string, version string) (err erro
 		Version: strings.TrimPrefix(version, "v"),
 	})
 }
-
-func loadManifest(path string) (v release.Manifest, err error) {
-	fb, err := ioutil.ReadFile(path)
-	if err != nil {
-		return release.Manifest{}, err
-	}
-
-	if err = json.Unmarshal(fb, &v); err != nil {
-		return release.Manifest{}, err
-	}
-
-	return v, nil
-}
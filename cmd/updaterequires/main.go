@@ -1,10 +1,8 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 
 	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
@@ -15,12 +13,14 @@ import (
 
 var config = struct {
 	ReleaseManifestPath string
+	VersionLine         string
 
 	Force bool
 }{}
 
 func init() {
 	flag.StringVar(&config.ReleaseManifestPath, "release", "", "file path to a release manifest containing module tags to be released overlayed")
+	flag.StringVar(&config.VersionLine, "version-line", "", "major and minor version line, (e.g. v1.24), to update repository module requires within, defaults to the release manifest's version line")
 	flag.BoolVar(&config.Force, "force", false, "force module versions regardless of the current recorded version")
 }
 
@@ -37,50 +37,70 @@ func main() {
 		log.Fatalf("failed to retrieve git tags: %v", err)
 	}
 
-	dependencies, err := getDependencies(repoRootPath)
+	repoConfig, err := repotools.LoadConfig(repoRootPath)
 	if err != nil {
-		log.Fatalf("failed to load dependency versions: %v", err)
+		log.Fatalf("failed to load repository configuration: %v", err)
 	}
 
+	versionLine := config.VersionLine
 	if len(config.ReleaseManifestPath) > 0 {
-		if err := applyOverlayTags(config.ReleaseManifestPath, tags); err != nil {
-			log.Fatalf("failed to apply tag overlay: %v", err)
+		manifest, err := release.LoadManifest(config.ReleaseManifestPath)
+		if err != nil {
+			log.Fatalf("failed to load release manifest: %v", err)
 		}
-	}
+		applyOverlayTags(manifest, tags)
 
-	if err := gomod.UpdateRequires(repoRootPath, tags, dependencies, config.Force); err != nil {
-		log.Fatalf("failed to update module dependencies: %v", err)
+		if len(versionLine) == 0 {
+			versionLine = manifest.VersionLine
+		}
 	}
-}
 
-func applyOverlayTags(path string, tags git.ModuleTags) error {
-	fBytes, err := ioutil.ReadFile(path)
+	versionLines, err := getVersionLines(repoConfig, versionLine)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		log.Fatalf("failed to load version lines: %v", err)
 	}
 
-	var manifest release.Manifest
-
-	if err := json.Unmarshal(fBytes, &manifest); err != nil {
-		return err
+	if err := gomod.UpdateRequires(repoRootPath, tags, repoConfig.Dependencies, config.Force, versionLines); err != nil {
+		log.Fatalf("failed to update module dependencies: %v", err)
 	}
+}
 
+func applyOverlayTags(manifest release.Manifest, tags git.ModuleTags) {
 	for _, tag := range manifest.Tags {
 		if len(tag) == 0 {
 			continue
 		}
 		tags.Add(tag)
 	}
-
-	return nil
 }
 
-func getDependencies(path string) (map[string]string, error) {
-	loadConfig, err := repotools.LoadConfig(path)
-	if err != nil {
-		return nil, err
+// getVersionLines returns the UpdateRequires option setting the repository wide version line, and the version lines
+// of modules configured with one.
+func getVersionLines(repoConfig repotools.Config, versionLine string) (func(o *gomod.UpdateRequiresOptions), error) {
+	if len(versionLine) > 0 {
+		var err error
+		versionLine, err = release.ParseVersionLine(versionLine)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	moduleVersionLines := make(map[string]string)
+	for relPath, moduleConfig := range repoConfig.Modules {
+		if len(moduleConfig.VersionLine) == 0 {
+			continue
+		}
+		line, err := release.ParseVersionLine(moduleConfig.VersionLine)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", relPath, err)
+		}
+		moduleVersionLines[relPath] = line
 	}
-	return loadConfig.Dependencies, nil
+
+	return func(o *gomod.UpdateRequiresOptions) {
+		o.VersionLine = versionLine
+		o.ModuleVersionLines = moduleVersionLines
+	}, nil
 }
 
 func getRepoTags(path string) (git.ModuleTags, error) {
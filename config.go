@@ -1,16 +1,23 @@
 package repotools
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/pelletier/go-toml"
 )
 
 const toolingConfigFile = "modman.toml"
 
+// DefaultRepositoryChangelogPath is the default location of the repository's release summary CHANGELOG relative to
+// the repository root.
+const DefaultRepositoryChangelogPath = "CHANGELOG.md"
+
 // ModuleConfig is the configuration for the repository module
 type ModuleConfig struct {
 	// Indicates that the given module should not be tagged (released)
@@ -22,12 +29,83 @@ type ModuleConfig struct {
 	// The package alternative location relative to the module where the go_module_metadata.go should be written.
 	// By default this file is written in the location of the module root where the `go.mod` is located.
 	MetadataPackage string `toml:"metadata_package,omitempty"`
+
+	// The major and minor version line, (e.g. v1.24), the module is released from. When set the module's changes are
+	// determined from the latest tag within the version line, and only patch versions are released.
+	VersionLine string `toml:"version_line,omitempty"`
+}
+
+// ChangelogConfig is the configuration for the CHANGELOG files generated for a release.
+type ChangelogConfig struct {
+	// The slash separated path relative to the repository root where the repository release summary CHANGELOG is
+	// written. Defaults to DefaultRepositoryChangelogPath.
+	RepositoryPath string `toml:"repository_path,omitempty"`
+
+	// The slash separated path relative to the repository root of a Go text/template file that overrides the
+	// "entry" and "summary" templates of the repository release summary CHANGELOG.
+	RepositoryTemplate string `toml:"repository_template,omitempty"`
+
+	// The slash separated path relative to the repository root of a Go text/template file that replaces the
+	// template used for each module's CHANGELOG entry.
+	ModuleTemplate string `toml:"module_template,omitempty"`
+
+	// The URL of the repository, for example "https://github.com/aws/aws-sdk-go-v2", used to link the pull requests,
+	// issues, and authors referenced by change annotations.
+	RepositoryURL string `toml:"repository_url,omitempty"`
+
+	// Additional change types that may be used by change annotations, keyed by the change type name.
+	ChangeTypes map[string]ChangeTypeConfig `toml:"change_types,omitempty"`
+}
+
+// ChangeTypeConfig is the configuration for a repository-defined change annotation type.
+type ChangeTypeConfig struct {
+	// The CHANGELOG heading annotations of the change type are listed under.
+	Heading string `toml:"heading"`
+
+	// The semver increment for modules with annotations of the change type. One of "major", "release", "minor",
+	// "patch", or "default". Defaults to "default", the version selector's default behavior.
+	Increment string `toml:"increment,omitempty"`
+
+	// The order annotations of the change type are sorted in when summarizing changes, higher precedence first.
+	Precedence int `toml:"precedence,omitempty"`
+}
+
+// RepositoryChangelogPath returns the slash separated path of the repository release summary CHANGELOG relative to
+// the repository root.
+func (c ChangelogConfig) RepositoryChangelogPath() string {
+	if len(c.RepositoryPath) == 0 {
+		return DefaultRepositoryChangelogPath
+	}
+	return path.Clean(c.RepositoryPath)
+}
+
+// validate returns an error if the repository release summary CHANGELOG path is not a file within the repository.
+func (c ChangelogConfig) validate() error {
+	if len(c.RepositoryPath) == 0 {
+		return nil
+	}
+
+	p := path.Clean(c.RepositoryPath)
+	if path.IsAbs(p) || filepath.IsAbs(filepath.FromSlash(p)) || p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("invalid changelog repository_path %q, must be a file path relative to the repository root",
+			c.RepositoryPath)
+	}
+
+	return nil
+}
+
+// RootModuleChangelog returns whether the root module has its own CHANGELOG. The root module's CHANGELOG is only
+// written when the repository release summary has been configured to be written to a different location.
+func (c ChangelogConfig) RootModuleChangelog() bool {
+	return c.RepositoryChangelogPath() != DefaultRepositoryChangelogPath
 }
 
 // Config is a configuration file for describing how modules and dependencies are managed.
 type Config struct {
 	Modules      map[string]ModuleConfig `toml:"modules,omitempty"`
 	Dependencies map[string]string       `toml:"dependencies,omitempty"`
+	Changelog    ChangelogConfig         `toml:"changelog,omitempty"`
+	Editor       EditorConfig            `toml:"editor,omitempty"`
 }
 
 func newConfig() Config {
@@ -62,6 +140,10 @@ func ReadConfig(reader io.Reader) (Config, error) {
 		return Config{}, err
 	}
 
+	if err = c.Changelog.validate(); err != nil {
+		return Config{}, err
+	}
+
 	return c, nil
 }
 
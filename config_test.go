@@ -0,0 +1,105 @@
+package repotools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pelletier/go-toml"
+)
+
+func TestReadConfig(t *testing.T) {
+	cases := map[string]struct {
+		Config                    string
+		ExpectChangelogPath       string
+		ExpectRootModuleChangelog bool
+		ExpectErr                 string
+	}{
+		"default": {
+			ExpectChangelogPath: "CHANGELOG.md",
+		},
+		"repository changelog path": {
+			Config: `
+[changelog]
+repository_path = "./docs/RELEASES.md"
+`,
+			ExpectChangelogPath:       "docs/RELEASES.md",
+			ExpectRootModuleChangelog: true,
+		},
+		"explicit default path": {
+			Config: `
+[changelog]
+repository_path = "CHANGELOG.md"
+`,
+			ExpectChangelogPath: "CHANGELOG.md",
+		},
+		"absolute repository changelog path": {
+			Config: `
+[changelog]
+repository_path = "/etc/CHANGELOG.md"
+`,
+			ExpectErr: `invalid changelog repository_path "/etc/CHANGELOG.md"`,
+		},
+		"repository changelog path outside repository": {
+			Config: `
+[changelog]
+repository_path = "docs/../../CHANGELOG.md"
+`,
+			ExpectErr: `invalid changelog repository_path "docs/../../CHANGELOG.md"`,
+		},
+		"repository changelog path is repository root": {
+			Config: `
+[changelog]
+repository_path = "./"
+`,
+			ExpectErr: `invalid changelog repository_path "./"`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			config, err := ReadConfig(strings.NewReader(tt.Config))
+			if len(tt.ExpectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := tt.ExpectChangelogPath, config.Changelog.RepositoryChangelogPath(); e != a {
+				t.Errorf("expect %v changelog path, got %v", e, a)
+			}
+			if e, a := tt.ExpectRootModuleChangelog, config.Changelog.RootModuleChangelog(); e != a {
+				t.Errorf("expect %v root module changelog, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	config := newConfig()
+	config.Modules["a"] = ModuleConfig{NoTag: true}
+	config.Changelog.RepositoryPath = "RELEASES.md"
+	config.Changelog.ChangeTypes = map[string]ChangeTypeConfig{
+		"performance": {Heading: "Performance", Increment: "patch", Precedence: 35},
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Order(toml.OrderAlphabetical).Encode(config); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	actual, err := ReadConfig(&buf)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if diff := cmp.Diff(config, actual); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
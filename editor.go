@@ -3,6 +3,9 @@ package repotools
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 const defaultEditor = "vim"
@@ -16,10 +19,82 @@ var allowedEditors = map[string]struct{}{
 	"edit":  {},
 	"gedit": {},
 	"emacs": {},
+	"code":  {},
+	"subl":  {},
+	"hx":    {},
+	"micro": {},
+}
+
+// EditorConfig is the configuration for the editor used for interactive file edits.
+type EditorConfig struct {
+	// Additional editor commands allowed to be used for interactive file edits.
+	Allowed []string `toml:"allowed,omitempty"`
+
+	// Disables the allowed editors check, permitting any editor command.
+	AllowAny bool `toml:"allow_any,omitempty"`
+}
+
+// IsAllowed returns whether the editor command is allowed. Commands without a path, (e.g. "vim"), are allowed if they
+// are a well known editor or configured in Allowed. Commands with a path are only allowed if the path is absolute and
+// configured in Allowed, (e.g. "/usr/local/bin/vim"), as the base name of an arbitrary path, (e.g. "/tmp/x/vim"), does
+// not identify the editor.
+func (c EditorConfig) IsAllowed(editor string) bool {
+	if c.AllowAny {
+		return true
+	}
+
+	if !strings.ContainsAny(editor, `/\`) {
+		if _, ok := allowedEditors[editor]; ok {
+			return true
+		}
+		for _, allowed := range c.Allowed {
+			if allowed == editor {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !filepath.IsAbs(editor) {
+		return false
+	}
+	for _, allowed := range c.Allowed {
+		if filepath.IsAbs(allowed) && filepath.Clean(allowed) == filepath.Clean(editor) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedEditors returns the sorted well known and configured editor commands that are allowed.
+func (c EditorConfig) allowedEditors() []string {
+	editors := make([]string, 0, len(allowedEditors)+len(c.Allowed))
+	for editor := range allowedEditors {
+		editors = append(editors, editor)
+	}
+	for _, editor := range c.Allowed {
+		if _, ok := allowedEditors[editor]; !ok {
+			editors = append(editors, editor)
+		}
+	}
+	sort.Strings(editors)
+	return editors
 }
 
 // GetEditorTool returns the editor tool to use for interactive file edits.
+//
+// Deprecated: Use GetEditorCommand, which supports editor commands with arguments.
 func GetEditorTool() (string, error) {
+	command, err := GetEditorCommand(EditorConfig{})
+	if err != nil {
+		return "", err
+	}
+	return command[0], nil
+}
+
+// GetEditorCommand returns the editor command, and its arguments, to use for interactive file edits. The command is
+// parsed from the VISUAL or EDITOR environment variables, for example "code --wait", and must be allowed by config.
+func GetEditorCommand(config EditorConfig) ([]string, error) {
 	editor := os.Getenv("VISUAL")
 	if editor == "" {
 		editor = os.Getenv("EDITOR")
@@ -29,9 +104,66 @@ func GetEditorTool() (string, error) {
 		}
 	}
 
-	if _, ok := allowedEditors[editor]; !ok {
-		return "", fmt.Errorf("unknown editor %q not allowed, %v", editor, allowedEditors)
+	command, err := splitCommandLine(editor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid editor %q, %w", editor, err)
+	}
+	if len(command) == 0 {
+		return nil, fmt.Errorf("invalid editor %q, no command", editor)
+	}
+
+	if !config.IsAllowed(command[0]) {
+		return nil, fmt.Errorf("unknown editor %q not allowed, %v", command[0], config.allowedEditors())
+	}
+
+	return command, nil
+}
+
+// splitCommandLine splits the command line into its whitespace separated fields. Single quotes, double quotes, and
+// backslash escapes may be used to include whitespace in a field.
+func splitCommandLine(v string) (fields []string, err error) {
+	var sb strings.Builder
+	var inField, escaped bool
+	var quote rune
+
+	for _, r := range v {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inField = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				sb.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inField = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inField {
+				fields = append(fields, sb.String())
+				sb.Reset()
+				inField = false
+			}
+		default:
+			sb.WriteRune(r)
+			inField = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if escaped {
+		return nil, fmt.Errorf("unterminated escape")
+	}
+	if inField {
+		fields = append(fields, sb.String())
 	}
 
-	return editor, nil
+	return fields, nil
 }
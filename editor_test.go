@@ -4,6 +4,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestGetEditorTool(t *testing.T) {
@@ -76,3 +78,83 @@ func TestGetEditorTool(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEditorCommand(t *testing.T) {
+	cases := map[string]struct {
+		Editor        string
+		Config        EditorConfig
+		ExpectCommand []string
+		ExpectErr     string
+	}{
+		"editor with arguments": {
+			Editor:        `code --wait`,
+			ExpectCommand: []string{"code", "--wait"},
+		},
+		"editor path": {
+			Editor:        `/usr/local/bin/subl -w`,
+			Config:        EditorConfig{Allowed: []string{"/usr/local/bin/subl"}},
+			ExpectCommand: []string{"/usr/local/bin/subl", "-w"},
+		},
+		"editor path not configured": {
+			Editor:    `/tmp/x/vim`,
+			ExpectErr: `unknown editor "/tmp/x/vim" not allowed`,
+		},
+		"relative editor path": {
+			Editor:    `./vim`,
+			Config:    EditorConfig{Allowed: []string{"./vim"}},
+			ExpectErr: `unknown editor "./vim" not allowed`,
+		},
+		"quoted arguments": {
+			Editor:        `"/opt/my editor/bin/ed" --title 'change annotation' a\ b`,
+			Config:        EditorConfig{Allowed: []string{"/opt/my editor/bin/ed"}},
+			ExpectCommand: []string{"/opt/my editor/bin/ed", "--title", "change annotation", "a b"},
+		},
+		"configured editor": {
+			Editor:        `kak -n`,
+			Config:        EditorConfig{Allowed: []string{"kak"}},
+			ExpectCommand: []string{"kak", "-n"},
+		},
+		"allow any editor": {
+			Editor:        `unknownCmd -x`,
+			Config:        EditorConfig{AllowAny: true},
+			ExpectCommand: []string{"unknownCmd", "-x"},
+		},
+		"unknown editor": {
+			Editor:    `unknownCmd -x`,
+			ExpectErr: `unknown editor "unknownCmd"`,
+		},
+		"unknown editor lists configured editors": {
+			Editor:    `unknownCmd -x`,
+			Config:    EditorConfig{Allowed: []string{"kak", "/opt/zed/bin/zed"}},
+			ExpectErr: `[/opt/zed/bin/zed code edit emacs gedit gvim hx kak micro nano nvim subl vi vim]`,
+		},
+		"unterminated quote": {
+			Editor:    `code "--wait`,
+			ExpectErr: `unterminated quote`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("VISUAL", c.Editor)
+
+			command, err := GetEditorCommand(c.Config)
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if diff := cmp.Diff(c.ExpectCommand, command); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -64,13 +64,16 @@ func Git(path string, arguments ...string) (output []byte, err error) {
 //	Path: service/s3     Version: v1.2.3 => service/s3/v1.2.3
 //	Path: service/s3/v2  Version: v2.2.3 => service/s3/v2.2.3
 //	Path: service/s3/v3  Version: v2.2.3 => error
+//	Path: v2             Version: v2.2.3 => v2.2.3
 func ToModuleTag(modulePath string, version string) (string, error) {
 	major := semver.Major(version)
 	if len(major) == 0 {
 		return "", fmt.Errorf("invalid semantic version: %v", major)
 	}
 
-	prefix, pathMajor, ok := module.SplitPathVersion(modulePath)
+	// Anchor the path to the repository root so that root module major
+	// versions, (e.g. v2), are split from the path.
+	prefix, pathMajor, ok := module.SplitPathVersion("./" + modulePath)
 	if !ok {
 		return "", fmt.Errorf("invalid module path version")
 	}
@@ -82,6 +85,29 @@ func ToModuleTag(modulePath string, version string) (string, error) {
 	return path.Join(prefix, version), nil
 }
 
+// ModuleTagPath returns the relative module path that identifies the module's tags for the module located at the
+// relative repository path with the given go.mod module path. Modules that have had their major version updated in
+// place, without a major version subdirectory, are identified by the relative path joined with the major version
+// suffix of the module path.
+// For example:
+//
+//	Path: service/s3     Module: example.com/service/s3     => service/s3
+//	Path: service/s3/v2  Module: example.com/service/s3/v2  => service/s3/v2
+//	Path: service/s3     Module: example.com/service/s3/v2  => service/s3/v2
+func ModuleTagPath(relPath, modulePath string) string {
+	_, pathMajor, ok := module.SplitPathVersion(modulePath)
+	if !ok || len(pathMajor) == 0 {
+		return relPath
+	}
+
+	major := strings.TrimPrefix(pathMajor, "/")
+	if path.Base(relPath) == major {
+		return relPath
+	}
+
+	return path.Join(relPath, major)
+}
+
 // ModuleTags is a map of module paths to a slice of tagged Go semver versions.
 // Root module tags will be placed in the map at ".". Major versions > v1 will be
 // added to the map with the semver major version appended to the module path.
@@ -107,6 +133,18 @@ func (r ModuleTags) Latest(module string) (string, bool) {
 	return r[module][0], true
 }
 
+// LatestInLine returns the latest tag for the given relative module path within the version line. The version line is
+// either a semver major version, (e.g. v1), or major and minor version, (e.g. v1.24). Returns false if the module has
+// no version in the line.
+func (r ModuleTags) LatestInLine(module, line string) (string, bool) {
+	for _, version := range r[module] {
+		if semver.Major(version) == line || semver.MajorMinor(version) == line {
+			return version, true
+		}
+	}
+	return "", false
+}
+
 // Add adds the given tag to the ModuleTags
 func (r ModuleTags) Add(tag string) bool {
 	module, version, ok := parseTag(tag)
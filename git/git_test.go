@@ -39,6 +39,11 @@ func TestToTag(t *testing.T) {
 			Version:  "v1.3.4",
 			Expected: "service/s3/volumetric/v1.3.4",
 		},
+		{
+			Path:     "v2",
+			Version:  "v2.3.4",
+			Expected: "v2.3.4",
+		},
 		{
 			Path:    "service/s3/v2",
 			Version: "v1.3.4",
@@ -149,3 +154,80 @@ func TestModuleTags_Add(t *testing.T) {
 		t.Errorf("%s", diff)
 	}
 }
+
+func TestModuleTags_LatestInLine(t *testing.T) {
+	moduleTags := git.ParseModuleTags([]string{"v1.26.0", "v1.24.2", "v1.24.10", "v1.24.11-preview", "v1.23.0"})
+
+	tests := map[string]struct {
+		Line     string
+		Expected string
+		OK       bool
+	}{
+		"major minor line": {
+			Line:     "v1.24",
+			Expected: "v1.24.11-preview",
+			OK:       true,
+		},
+		"major line": {
+			Line:     "v1",
+			Expected: "v1.26.0",
+			OK:       true,
+		},
+		"unknown line": {
+			Line: "v1.25",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			latest, ok := moduleTags.LatestInLine(".", tt.Line)
+			if tt.OK != ok {
+				t.Fatalf("expect %v, got %v", tt.OK, ok)
+			}
+			if tt.Expected != latest {
+				t.Errorf("expect %v, got %v", tt.Expected, latest)
+			}
+		})
+	}
+}
+
+func TestModuleTagPath(t *testing.T) {
+	tests := map[string]struct {
+		RelPath    string
+		ModulePath string
+		Expected   string
+	}{
+		"root module": {
+			RelPath:    ".",
+			ModulePath: "github.com/aws/smithy-go",
+			Expected:   ".",
+		},
+		"root module major version": {
+			RelPath:    ".",
+			ModulePath: "github.com/aws/smithy-go/v2",
+			Expected:   "v2",
+		},
+		"sub module": {
+			RelPath:    "service/s3",
+			ModulePath: "github.com/aws/aws-sdk-go-v2/service/s3",
+			Expected:   "service/s3",
+		},
+		"major version subdirectory": {
+			RelPath:    "service/s3/v2",
+			ModulePath: "github.com/aws/aws-sdk-go-v2/service/s3/v2",
+			Expected:   "service/s3/v2",
+		},
+		"major version in place": {
+			RelPath:    "service/s3",
+			ModulePath: "github.com/aws/aws-sdk-go-v2/service/s3/v3",
+			Expected:   "service/s3/v3",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := git.ModuleTagPath(tt.RelPath, tt.ModulePath); got != tt.Expected {
+				t.Errorf("expect %v, got %v", tt.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	logFieldSeparator  = "\x00"
+	logRecordSeparator = "\x1e"
+)
+
+// LogEntry is a commit reported by git-log.
+type LogEntry struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Message     string
+}
+
+// Log returns the commits reachable from the given revisions, as interpreted by git-log. For example a single
+// commit with "-1 <commit>", or a range of commits with "<from>..<to>".
+func Log(repository string, revisions ...string) ([]LogEntry, error) {
+	arguments := []string{"log", "--format=%H%x00%an%x00%ae%x00%B%x1e"}
+	arguments = append(arguments, revisions...)
+
+	output, err := Git(repository, arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLog(string(output))
+}
+
+func parseLog(output string) (entries []LogEntry, err error) {
+	for _, record := range strings.Split(output, logRecordSeparator) {
+		record = strings.TrimLeft(record, "\n")
+		if len(record) == 0 {
+			continue
+		}
+
+		fields := strings.SplitN(record, logFieldSeparator, 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid git-log record: %q", record)
+		}
+
+		entries = append(entries, LogEntry{
+			Hash:        fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			Message:     strings.TrimSpace(fields[3]),
+		})
+	}
+	return entries, nil
+}
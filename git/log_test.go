@@ -0,0 +1,39 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLog(t *testing.T) {
+	output := "a1b2c3\x00Jane Doe\x00123+jdoe@users.noreply.github.com\x00Fix a thing (#12)\n\nFixes #3\n\x1e\n" +
+		"d4e5f6\x00John Doe\x00john@example.com\x00Add a feature\n\x1e\n"
+
+	entries, err := parseLog(output)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	want := []LogEntry{
+		{
+			Hash:        "a1b2c3",
+			AuthorName:  "Jane Doe",
+			AuthorEmail: "123+jdoe@users.noreply.github.com",
+			Message:     "Fix a thing (#12)\n\nFixes #3",
+		},
+		{
+			Hash:        "d4e5f6",
+			AuthorName:  "John Doe",
+			AuthorEmail: "john@example.com",
+			Message:     "Add a feature",
+		},
+	}
+	if diff := cmp.Diff(want, entries); len(diff) > 0 {
+		t.Error(diff)
+	}
+
+	if _, err := parseLog("a1b2c3\x00Jane Doe\x1e"); err == nil {
+		t.Error("expect error, got none")
+	}
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an ObjectStore that holds objects and references in memory. MemoryStore can be used to construct
+// repositories for tests without requiring a Git checkout.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	objects map[Hash]packObject
+	refs    map[string]Hash
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		objects: make(map[Hash]packObject),
+		refs:    make(map[string]Hash),
+	}
+}
+
+// Object returns the type and content of the object identified by the hash.
+func (s *MemoryStore) Object(h Hash) (ObjectType, []byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, ok := s.objects[h]
+	if !ok {
+		return UnknownObject, nil, &ObjectNotFoundError{Hash: h}
+	}
+	return o.Type, o.Content, nil
+}
+
+// Refs returns the references of the store mapped to the object they refer to.
+func (s *MemoryStore) Refs() (map[string]Hash, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	refs := make(map[string]Hash, len(s.refs))
+	for name, h := range s.refs {
+		refs[name] = h
+	}
+	return refs, nil
+}
+
+// WriteObject adds the object to the store, returning its hash.
+func (s *MemoryStore) WriteObject(t ObjectType, content []byte) Hash {
+	h := hashObject(t, content)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[h] = packObject{
+		Type:    t,
+		Content: append([]byte{}, content...),
+	}
+	return h
+}
+
+// SetRef sets the reference name, (e.g. HEAD or refs/heads/main), to the object hash.
+func (s *MemoryStore) SetRef(name string, h Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refs[name] = h
+}
+
+// WriteCommit adds a commit to the store whose tree contains the provided files, with the given parent commits.
+// Files are keyed by their slash separated path relative to the root of the tree. Returns the commit hash.
+func (s *MemoryStore) WriteCommit(files map[string]string, message string, parents ...Hash) (Hash, error) {
+	tree, err := s.writeTree(files)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "tree %s\n", tree)
+	for _, parent := range parents {
+		fmt.Fprintf(&sb, "parent %s\n", parent)
+	}
+	sb.WriteString("author repotools <repotools@example.com> 0 +0000\n")
+	sb.WriteString("committer repotools <repotools@example.com> 0 +0000\n")
+	sb.WriteString("\n")
+	sb.WriteString(message)
+	sb.WriteString("\n")
+
+	return s.WriteObject(CommitObject, []byte(sb.String())), nil
+}
+
+// WriteTag adds an annotated tag object for the target commit to the store, and sets the refs/tags/<name> reference
+// to the tag object. Returns the tag object hash.
+func (s *MemoryStore) WriteTag(name string, target Hash, message string) Hash {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "object %s\n", target)
+	fmt.Fprintf(&sb, "type %s\n", CommitObject)
+	fmt.Fprintf(&sb, "tag %s\n", name)
+	sb.WriteString("tagger repotools <repotools@example.com> 0 +0000\n")
+	sb.WriteString("\n")
+	sb.WriteString(message)
+	sb.WriteString("\n")
+
+	h := s.WriteObject(TagObject, []byte(sb.String()))
+	s.SetRef("refs/tags/"+name, h)
+	return h
+}
+
+// writeTree adds the blobs and trees for the files to the store, returning the root tree hash.
+func (s *MemoryStore) writeTree(files map[string]string) (Hash, error) {
+	type dir struct {
+		files map[string]string
+		dirs  map[string]map[string]string
+	}
+	root := dir{
+		files: map[string]string{},
+		dirs:  map[string]map[string]string{},
+	}
+
+	for name, content := range files {
+		split := strings.SplitN(name, "/", 2)
+		if len(split[0]) == 0 {
+			return Hash{}, fmt.Errorf("invalid file path, %q", name)
+		}
+		if len(split) == 1 {
+			root.files[split[0]] = content
+			continue
+		}
+		if _, ok := root.dirs[split[0]]; !ok {
+			root.dirs[split[0]] = map[string]string{}
+		}
+		root.dirs[split[0]][split[1]] = content
+	}
+
+	var entries []TreeEntry
+	for name, content := range root.files {
+		entries = append(entries, TreeEntry{
+			Mode: 0o100644,
+			Name: name,
+			Hash: s.WriteObject(BlobObject, []byte(content)),
+		})
+	}
+	for name, dirFiles := range root.dirs {
+		if _, ok := root.files[name]; ok {
+			return Hash{}, fmt.Errorf("path is both a file and directory, %q", name)
+		}
+		h, err := s.writeTree(dirFiles)
+		if err != nil {
+			return Hash{}, err
+		}
+		entries = append(entries, TreeEntry{
+			Mode: treeMode,
+			Name: name,
+			Hash: h,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].sortName() < entries[j].sortName()
+	})
+
+	return s.WriteObject(TreeObject, encodeTreeObject(entries)), nil
+}
@@ -0,0 +1,213 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// Hash is the SHA-1 object identifier of a Git object.
+type Hash [sha1.Size]byte
+
+// ParseHash parses the hexadecimal string representation of a Git object identifier.
+func ParseHash(v string) (h Hash, err error) {
+	if len(v) != hex.EncodedLen(len(h)) {
+		return Hash{}, fmt.Errorf("invalid object hash length, %v", v)
+	}
+	if _, err = hex.Decode(h[:], []byte(v)); err != nil {
+		return Hash{}, fmt.Errorf("invalid object hash, %v, %w", v, err)
+	}
+	return h, nil
+}
+
+// String returns the hexadecimal representation of the hash.
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// ObjectType is the type of Git object.
+type ObjectType int
+
+// Git object types.
+const (
+	UnknownObject ObjectType = iota
+	CommitObject
+	TreeObject
+	BlobObject
+	TagObject
+)
+
+// String returns the Git name for the object type.
+func (t ObjectType) String() string {
+	switch t {
+	case CommitObject:
+		return "commit"
+	case TreeObject:
+		return "tree"
+	case BlobObject:
+		return "blob"
+	case TagObject:
+		return "tag"
+	default:
+		return ""
+	}
+}
+
+func parseObjectType(v string) ObjectType {
+	switch v {
+	case "commit":
+		return CommitObject
+	case "tree":
+		return TreeObject
+	case "blob":
+		return BlobObject
+	case "tag":
+		return TagObject
+	default:
+		return UnknownObject
+	}
+}
+
+// hashObject computes the identifier of an object with the given type and content.
+func hashObject(t ObjectType, content []byte) Hash {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s %d\x00", t, len(content))
+	h.Write(content)
+
+	var sum Hash
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// treeMode is the file mode of a tree entry that refers to a sub-tree.
+const treeMode = 0o040000
+
+// TreeEntry is an entry of a Git tree object.
+type TreeEntry struct {
+	Mode uint32
+	Name string
+	Hash Hash
+}
+
+// IsTree returns whether the entry refers to a sub-tree.
+func (e TreeEntry) IsTree() bool {
+	return e.Mode == treeMode
+}
+
+// sortName returns the name used to order the entry within its tree. Git orders sub-trees as if their name had a
+// trailing slash.
+func (e TreeEntry) sortName() string {
+	if e.IsTree() {
+		return e.Name + "/"
+	}
+	return e.Name
+}
+
+func parseTreeObject(content []byte) (entries []TreeEntry, err error) {
+	for len(content) > 0 {
+		sp := bytes.IndexByte(content, ' ')
+		if sp == -1 {
+			return nil, fmt.Errorf("malformed tree entry mode")
+		}
+		mode, err := strconv.ParseUint(string(content[:sp]), 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed tree entry mode, %w", err)
+		}
+		content = content[sp+1:]
+
+		nul := bytes.IndexByte(content, 0)
+		if nul == -1 || len(content) < nul+1+len(Hash{}) {
+			return nil, fmt.Errorf("malformed tree entry name")
+		}
+		entry := TreeEntry{
+			Mode: uint32(mode),
+			Name: string(content[:nul]),
+		}
+		copy(entry.Hash[:], content[nul+1:])
+		content = content[nul+1+len(entry.Hash):]
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func encodeTreeObject(entries []TreeEntry) []byte {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "%o %s\x00", entry.Mode, entry.Name)
+		buf.Write(entry.Hash[:])
+	}
+	return buf.Bytes()
+}
+
+// commitObject is the subset of a Git commit object needed for comparing trees.
+type commitObject struct {
+	Tree    Hash
+	Parents []Hash
+}
+
+func parseCommitObject(content []byte) (c commitObject, err error) {
+	err = parseHeaders(content, func(key, value string) error {
+		switch key {
+		case "tree":
+			c.Tree, err = ParseHash(value)
+		case "parent":
+			var parent Hash
+			parent, err = ParseHash(value)
+			c.Parents = append(c.Parents, parent)
+		}
+		return err
+	})
+	return c, err
+}
+
+// tagObject is the subset of a Git annotated tag object needed for resolving the tagged object.
+type tagObject struct {
+	Object Hash
+	Type   ObjectType
+}
+
+func parseTagObject(content []byte) (t tagObject, err error) {
+	err = parseHeaders(content, func(key, value string) error {
+		switch key {
+		case "object":
+			t.Object, err = ParseHash(value)
+		case "type":
+			t.Type = parseObjectType(value)
+		}
+		return err
+	})
+	return t, err
+}
+
+// parseHeaders calls fn for each header line of a commit or tag object, stopping at the blank line separating the
+// headers from the message.
+func parseHeaders(content []byte, fn func(key, value string) error) error {
+	for len(content) > 0 {
+		var line []byte
+		if i := bytes.IndexByte(content, '\n'); i != -1 {
+			line, content = content[:i], content[i+1:]
+		} else {
+			line, content = content, nil
+		}
+
+		if len(line) == 0 {
+			return nil
+		}
+		// Continuation lines of multi-line headers, (e.g. gpgsig), are prefixed by a space.
+		if line[0] == ' ' {
+			continue
+		}
+
+		key, value := string(line), ""
+		if i := bytes.IndexByte(line, ' '); i != -1 {
+			key, value = string(line[:i]), string(line[i+1:])
+		}
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
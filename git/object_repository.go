@@ -0,0 +1,362 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ObjectRepository is a Reader that reads a repository's objects and references directly from an ObjectStore,
+// without invoking the git binary.
+//
+// Revisions can be specified as a full object hash, HEAD, or a tag, branch, or reference name. Paths are matched
+// as literal leading path components, glob patterns are not supported.
+type ObjectRepository struct {
+	store ObjectStore
+
+	refsOnce sync.Once
+	refs     map[string]Hash
+	refsErr  error
+}
+
+// NewObjectRepository returns an ObjectRepository reading from the provided store. The store's references are read
+// once when first needed.
+func NewObjectRepository(store ObjectStore) *ObjectRepository {
+	return &ObjectRepository{store: store}
+}
+
+// OpenRepository returns an ObjectRepository reading the object database of the Git repository located at path.
+func OpenRepository(path string) (*ObjectRepository, error) {
+	store, err := NewFileStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectRepository(store), nil
+}
+
+// Close closes the underlying store if it holds open resources.
+func (r *ObjectRepository) Close() error {
+	if c, ok := r.store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Tags returns a slice of the repository's tag names sorted by name.
+func (r *ObjectRepository) Tags() ([]string, error) {
+	refs, err := r.getRefs()
+	if err != nil {
+		return nil, err
+	}
+
+	const tagPrefix = "refs/tags/"
+
+	var tags []string
+	for name := range refs {
+		if strings.HasPrefix(name, tagPrefix) {
+			tags = append(tags, strings.TrimPrefix(name, tagPrefix))
+		}
+	}
+	sort.Strings(tags)
+
+	return tags, nil
+}
+
+// LsTree lists the files present in the tree-ish. An optional set of one or more paths can be provided to limit the
+// output file paths.
+func (r *ObjectRepository) LsTree(tree string, paths ...string) ([]string, error) {
+	h, err := r.resolveTree(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if err := r.listTree(h, "", newPathSpec(paths), &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Changes lists the files that have changed at the given paths between from and to commit-like references.
+func (r *ObjectRepository) Changes(from, to string, paths ...string) ([]string, error) {
+	fromTree, err := r.resolveTree(from)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := r.resolveTree(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if err := r.diffTrees(fromTree, toTree, "", newPathSpec(paths), &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Changed lists the files that changed for a specific commit or tag compared to its parent. Consistent with
+// git-diff-tree, no changes are reported for root commits or merge commits.
+func (r *ObjectRepository) Changed(commit string, paths ...string) ([]string, error) {
+	h, err := r.resolve(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := r.peelCommit(h)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Parents) != 1 {
+		return nil, nil
+	}
+
+	parent, err := r.peelCommit(c.Parents[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if err := r.diffTrees(parent.Tree, c.Tree, "", newPathSpec(paths), &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *ObjectRepository) getRefs() (map[string]Hash, error) {
+	r.refsOnce.Do(func() {
+		r.refs, r.refsErr = r.store.Refs()
+	})
+	return r.refs, r.refsErr
+}
+
+// resolve resolves the revision to an object hash. References are searched in the same order as git-rev-parse.
+func (r *ObjectRepository) resolve(rev string) (Hash, error) {
+	if h, err := ParseHash(rev); err == nil {
+		return h, nil
+	}
+
+	refs, err := r.getRefs()
+	if err != nil {
+		return Hash{}, err
+	}
+
+	for _, name := range []string{
+		rev,
+		"refs/" + rev,
+		"refs/tags/" + rev,
+		"refs/heads/" + rev,
+		"refs/remotes/" + rev,
+		"refs/remotes/" + rev + "/HEAD",
+	} {
+		if h, ok := refs[name]; ok {
+			return h, nil
+		}
+	}
+
+	return Hash{}, fmt.Errorf("unknown revision %v", rev)
+}
+
+// resolveTree resolves the tree-ish revision to a tree hash.
+func (r *ObjectRepository) resolveTree(rev string) (Hash, error) {
+	h, err := r.resolve(rev)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	for {
+		t, content, err := r.store.Object(h)
+		if err != nil {
+			return Hash{}, err
+		}
+
+		switch t {
+		case TreeObject:
+			return h, nil
+		case CommitObject:
+			c, err := parseCommitObject(content)
+			if err != nil {
+				return Hash{}, fmt.Errorf("failed to parse commit %v, %w", h, err)
+			}
+			return c.Tree, nil
+		case TagObject:
+			tag, err := parseTagObject(content)
+			if err != nil {
+				return Hash{}, fmt.Errorf("failed to parse tag %v, %w", h, err)
+			}
+			h = tag.Object
+		default:
+			return Hash{}, fmt.Errorf("%v is not a tree-ish, %v", rev, t)
+		}
+	}
+}
+
+// peelCommit resolves the object, dereferencing annotated tags, to a commit.
+func (r *ObjectRepository) peelCommit(h Hash) (commitObject, error) {
+	for {
+		t, content, err := r.store.Object(h)
+		if err != nil {
+			return commitObject{}, err
+		}
+
+		switch t {
+		case CommitObject:
+			c, err := parseCommitObject(content)
+			if err != nil {
+				return commitObject{}, fmt.Errorf("failed to parse commit %v, %w", h, err)
+			}
+			return c, nil
+		case TagObject:
+			tag, err := parseTagObject(content)
+			if err != nil {
+				return commitObject{}, fmt.Errorf("failed to parse tag %v, %w", h, err)
+			}
+			h = tag.Object
+		default:
+			return commitObject{}, fmt.Errorf("%v is not a commit, %v", h, t)
+		}
+	}
+}
+
+func (r *ObjectRepository) readTree(h Hash) ([]TreeEntry, error) {
+	if h == (Hash{}) {
+		return nil, nil
+	}
+
+	t, content, err := r.store.Object(h)
+	if err != nil {
+		return nil, err
+	}
+	if t != TreeObject {
+		return nil, fmt.Errorf("%v is not a tree, %v", h, t)
+	}
+
+	entries, err := parseTreeObject(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tree %v, %w", h, err)
+	}
+	return entries, nil
+}
+
+// listTree appends the paths of the files within the tree that match the path spec.
+func (r *ObjectRepository) listTree(h Hash, prefix string, spec pathSpec, files *[]string) error {
+	entries, err := r.readTree(h)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := path.Join(prefix, entry.Name)
+		if entry.IsTree() {
+			if !spec.MatchDir(name) {
+				continue
+			}
+			if err := r.listTree(entry.Hash, name, spec, files); err != nil {
+				return err
+			}
+			continue
+		}
+		if spec.Match(name) {
+			*files = append(*files, name)
+		}
+	}
+
+	return nil
+}
+
+// diffTrees appends the paths of the files that differ between the from and to trees that match the path spec.
+// Both trees are walked in Git's tree entry order, so the paths are in the same order as git-diff-tree.
+func (r *ObjectRepository) diffTrees(from, to Hash, prefix string, spec pathSpec, files *[]string) error {
+	fromEntries, err := r.readTree(from)
+	if err != nil {
+		return err
+	}
+	toEntries, err := r.readTree(to)
+	if err != nil {
+		return err
+	}
+
+	// diffEntry handles an entry that is only present in one of the trees, or has changed between the trees.
+	diffEntry := func(name string, fromEntry, toEntry TreeEntry) error {
+		name = path.Join(prefix, name)
+
+		if fromEntry.IsTree() || toEntry.IsTree() {
+			if !spec.MatchDir(name) {
+				return nil
+			}
+			return r.diffTrees(fromEntry.Hash, toEntry.Hash, name, spec, files)
+		}
+
+		if spec.Match(name) {
+			*files = append(*files, name)
+		}
+		return nil
+	}
+
+	var i, j int
+	for i < len(fromEntries) || j < len(toEntries) {
+		switch {
+		case j == len(toEntries) || (i < len(fromEntries) && fromEntries[i].sortName() < toEntries[j].sortName()):
+			err = diffEntry(fromEntries[i].Name, fromEntries[i], TreeEntry{})
+			i++
+		case i == len(fromEntries) || toEntries[j].sortName() < fromEntries[i].sortName():
+			err = diffEntry(toEntries[j].Name, TreeEntry{}, toEntries[j])
+			j++
+		default:
+			if fromEntries[i] != toEntries[j] {
+				err = diffEntry(toEntries[j].Name, fromEntries[i], toEntries[j])
+			}
+			i++
+			j++
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pathSpec is a set of paths that limits the files reported. Each path matches itself, and all paths nested within
+// it. An empty pathSpec matches all paths.
+type pathSpec []string
+
+func newPathSpec(paths []string) (spec pathSpec) {
+	for _, p := range paths {
+		p = path.Clean(p)
+		if p == "." {
+			return nil
+		}
+		spec = append(spec, p)
+	}
+	return spec
+}
+
+// Match returns whether the file path matches the path spec.
+func (s pathSpec) Match(name string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for _, p := range s {
+		if name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchDir returns whether the directory may contain files that match the path spec.
+func (s pathSpec) MatchDir(dir string) bool {
+	if s.Match(dir) {
+		return true
+	}
+	for _, p := range s {
+		if strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+package git_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestObjectRepository(t *testing.T) {
+	store := git.NewMemoryStore()
+
+	first, err := store.WriteCommit(map[string]string{
+		"go.mod":               "module example.com/repo",
+		"foo.go":               "package repo",
+		"service/s3/go.mod":    "module example.com/repo/service/s3",
+		"service/s3/api.go":    "package s3",
+		"service/s3x/api.go":   "package s3x",
+		"service/ec2/go.mod":   "module example.com/repo/service/ec2",
+		"service/ec2/api.go":   "package ec2",
+		"service/ec2/doc.go":   "package ec2",
+		"service/ec2/README":   "ec2",
+		"service/ec2/internal": "file",
+	}, "first commit")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	store.WriteTag("v1.0.0", first, "first release")
+	store.WriteTag("service/s3/v1.0.0", first, "first release")
+
+	second, err := store.WriteCommit(map[string]string{
+		"go.mod":                      "module example.com/repo",
+		"foo.go":                      "package repo",
+		"service/s3/go.mod":           "module example.com/repo/service/s3",
+		"service/s3/api.go":           "package s3 // updated",
+		"service/s3x/api.go":          "package s3x // updated",
+		"service/ec2/go.mod":          "module example.com/repo/service/ec2",
+		"service/ec2/api.go":          "package ec2",
+		"service/ec2/README":          "ec2",
+		"service/ec2/internal/foo.go": "package internal",
+	}, "second commit", first)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	store.SetRef("refs/heads/main", second)
+	store.SetRef("HEAD", second)
+
+	repo := git.NewObjectRepository(store)
+
+	t.Run("Tags", func(t *testing.T) {
+		tags, err := repo.Tags()
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if diff := cmp.Diff([]string{"service/s3/v1.0.0", "v1.0.0"}, tags); len(diff) > 0 {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("LsTree", func(t *testing.T) {
+		files, err := repo.LsTree("v1.0.0", "service/ec2")
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		expect := []string{
+			"service/ec2/README",
+			"service/ec2/api.go",
+			"service/ec2/doc.go",
+			"service/ec2/go.mod",
+			"service/ec2/internal",
+		}
+		if diff := cmp.Diff(expect, files); len(diff) > 0 {
+			t.Error(diff)
+		}
+	})
+
+	cases := map[string]struct {
+		From, To string
+		Paths    []string
+		Expect   []string
+	}{
+		"all changes": {
+			From: "v1.0.0",
+			To:   "HEAD",
+			Expect: []string{
+				"service/ec2/doc.go",
+				"service/ec2/internal",
+				"service/ec2/internal/foo.go",
+				"service/s3/api.go",
+				"service/s3x/api.go",
+			},
+		},
+		"repository root path": {
+			From:  "v1.0.0",
+			To:    "main",
+			Paths: []string{"."},
+			Expect: []string{
+				"service/ec2/doc.go",
+				"service/ec2/internal",
+				"service/ec2/internal/foo.go",
+				"service/s3/api.go",
+				"service/s3x/api.go",
+			},
+		},
+		"module path does not match sibling prefix": {
+			From:   "service/s3/v1.0.0",
+			To:     "HEAD",
+			Paths:  []string{"service/s3"},
+			Expect: []string{"service/s3/api.go"},
+		},
+		"no changes": {
+			From:  "v1.0.0",
+			To:    second.String(),
+			Paths: []string{"go.mod"},
+		},
+	}
+	for name, tt := range cases {
+		t.Run("Changes "+name, func(t *testing.T) {
+			files, err := repo.Changes(tt.From, tt.To, tt.Paths...)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.Expect, files); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("Changed", func(t *testing.T) {
+		files, err := repo.Changed("HEAD", "service/ec2")
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		expect := []string{
+			"service/ec2/doc.go",
+			"service/ec2/internal",
+			"service/ec2/internal/foo.go",
+		}
+		if diff := cmp.Diff(expect, files); len(diff) > 0 {
+			t.Error(diff)
+		}
+
+		files, err = repo.Changed("v1.0.0")
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if len(files) != 0 {
+			t.Errorf("expect no changes for root commit, got %v", files)
+		}
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		if _, err := repo.Changes("v9.9.9", "HEAD"); err == nil {
+			t.Errorf("expect error, got none")
+		}
+	})
+}
+
+func TestObjectRepository_MatchesExec(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "repotools")
+	t.Setenv("GIT_AUTHOR_EMAIL", "repotools@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "repotools")
+	t.Setenv("GIT_COMMITTER_EMAIL", "repotools@example.com")
+
+	mustGit := func(args ...string) {
+		t.Helper()
+		if _, err := git.Git(dir, args...); err != nil {
+			t.Fatalf("git %v failed, %v", args, err)
+		}
+	}
+	writeFiles := func(files map[string]string) {
+		t.Helper()
+		for name, content := range files {
+			p := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	mustGit("init", "-q")
+	writeFiles(map[string]string{
+		"go.mod":            "module example.com/repo\n",
+		"foo.go":            "package repo\n",
+		"service/s3/go.mod": "module example.com/repo/service/s3\n",
+		"service/s3/api.go": "package s3\n",
+		"service/ec2/a.go":  "package ec2\n",
+		"service/ec2/b.go":  "package ec2\n",
+	})
+	mustGit("add", "-A", ".")
+	mustGit("commit", "-q", "-m", "first")
+	mustGit("tag", "-a", "-m", "release", "v1.0.0")
+	mustGit("tag", "service/s3/v1.0.0")
+
+	writeFiles(map[string]string{
+		"service/s3/api.go":       "package s3\n\n// Updated\n",
+		"service/ec2/internal.go": "package ec2\n",
+	})
+	if err := os.Remove(filepath.Join(dir, "service", "ec2", "b.go")); err != nil {
+		t.Fatal(err)
+	}
+	mustGit("add", "-A", ".")
+	mustGit("commit", "-q", "-m", "second")
+
+	execRepo := git.NewExecRepository(dir)
+
+	compare := func(t *testing.T) {
+		objectRepo, err := git.OpenRepository(dir)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		defer objectRepo.Close()
+
+		calls := map[string]func(r git.Reader) ([]string, error){
+			"Tags": func(r git.Reader) ([]string, error) {
+				return r.Tags()
+			},
+			"LsTree": func(r git.Reader) ([]string, error) {
+				return r.LsTree("v1.0.0")
+			},
+			"LsTree paths": func(r git.Reader) ([]string, error) {
+				return r.LsTree("HEAD", "service/ec2")
+			},
+			"Changes": func(r git.Reader) ([]string, error) {
+				return r.Changes("v1.0.0", "HEAD")
+			},
+			"Changes paths": func(r git.Reader) ([]string, error) {
+				return r.Changes("service/s3/v1.0.0", "HEAD", "service/s3")
+			},
+			"Changed": func(r git.Reader) ([]string, error) {
+				return r.Changed("HEAD")
+			},
+		}
+		for name, call := range calls {
+			t.Run(name, func(t *testing.T) {
+				expect, err := call(execRepo)
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				actual, err := call(objectRepo)
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				if diff := cmp.Diff(expect, actual, cmpopts.EquateEmpty()); len(diff) > 0 {
+					t.Error(diff)
+				}
+			})
+		}
+	}
+
+	t.Run("loose objects", compare)
+
+	mustGit("gc", "-q", "--aggressive")
+	t.Run("packed objects", compare)
+}
@@ -0,0 +1,350 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Packfile object types.
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+// maxPackCacheEntries is the number of resolved objects cached per packfile to avoid repeatedly resolving delta
+// chains with common bases.
+const maxPackCacheEntries = 4096
+
+type packObject struct {
+	Type    ObjectType
+	Content []byte
+}
+
+// packFile provides access to the objects of a packfile using its index.
+type packFile struct {
+	file *os.File
+	size int64
+
+	hashes  []Hash
+	offsets []int64
+	fanout  [256]uint32
+
+	mu    sync.Mutex
+	cache map[int64]packObject
+}
+
+func openPackFile(idxPath string) (*packFile, error) {
+	idx, err := ioutil.ReadFile(idxPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pack := &packFile{
+		cache: make(map[int64]packObject),
+	}
+	if err := pack.parseIndex(idx); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(strings.TrimSuffix(idxPath, ".idx") + ".pack")
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	pack.file = f
+	pack.size = fi.Size()
+
+	return pack, nil
+}
+
+// parseIndex parses a version 1 or version 2 packfile index.
+func (p *packFile) parseIndex(idx []byte) error {
+	hashSize := len(Hash{})
+	version := 1
+	if len(idx) >= 8 && bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) {
+		version = int(binary.BigEndian.Uint32(idx[4:8]))
+		if version != 2 {
+			return fmt.Errorf("unsupported packfile index version %d", version)
+		}
+		idx = idx[8:]
+	}
+
+	if len(idx) < len(p.fanout)*4 {
+		return fmt.Errorf("truncated packfile index")
+	}
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(idx[i*4:])
+	}
+	idx = idx[len(p.fanout)*4:]
+	count := int(p.fanout[len(p.fanout)-1])
+
+	p.hashes = make([]Hash, count)
+	p.offsets = make([]int64, count)
+
+	if version == 1 {
+		const entrySize = 4 + 20
+		if len(idx) < count*entrySize {
+			return fmt.Errorf("truncated packfile index")
+		}
+		for i := 0; i < count; i++ {
+			entry := idx[i*entrySize:]
+			p.offsets[i] = int64(binary.BigEndian.Uint32(entry))
+			copy(p.hashes[i][:], entry[4:])
+		}
+		return nil
+	}
+
+	hashesEnd := count * hashSize
+	offsetsStart := hashesEnd + count*4 // skip CRC32 values
+	largeStart := offsetsStart + count*4
+	if len(idx) < largeStart {
+		return fmt.Errorf("truncated packfile index")
+	}
+	for i := 0; i < count; i++ {
+		copy(p.hashes[i][:], idx[i*hashSize:])
+
+		offset := binary.BigEndian.Uint32(idx[offsetsStart+i*4:])
+		if offset&0x80000000 == 0 {
+			p.offsets[i] = int64(offset)
+			continue
+		}
+
+		large := largeStart + int(offset&0x7fffffff)*8
+		if len(idx) < large+8 {
+			return fmt.Errorf("truncated packfile index large offsets")
+		}
+		p.offsets[i] = int64(binary.BigEndian.Uint64(idx[large:]))
+	}
+
+	return nil
+}
+
+// find returns the offset of the object within the packfile.
+func (p *packFile) find(h Hash) (int64, bool) {
+	var lo int
+	if h[0] > 0 {
+		lo = int(p.fanout[h[0]-1])
+	}
+	hi := int(p.fanout[h[0]])
+
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(p.hashes[lo+i][:], h[:]) >= 0
+	})
+	if i < hi && p.hashes[i] == h {
+		return p.offsets[i], true
+	}
+	return 0, false
+}
+
+// readObject reads the object at the offset within the packfile, resolving delta objects against their base. The
+// store is used to resolve delta bases that are referenced by hash.
+func (p *packFile) readObject(offset int64, store ObjectStore) (ObjectType, []byte, error) {
+	p.mu.Lock()
+	cached, ok := p.cache[offset]
+	p.mu.Unlock()
+	if ok {
+		return cached.Type, cached.Content, nil
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(p.file, offset, p.size-offset))
+
+	packType, size, err := readPackEntryHeader(r)
+	if err != nil {
+		return UnknownObject, nil, fmt.Errorf("failed to read packfile entry at %d, %w", offset, err)
+	}
+
+	var t ObjectType
+	var content []byte
+
+	switch packType {
+	case packCommit, packTree, packBlob, packTag:
+		// Packfile base object types share the values of ObjectType.
+		t = ObjectType(packType)
+		content, err = readAllZlib(r, int(size))
+	case packOfsDelta:
+		var baseOffset int64
+		baseOffset, err = readOfsDeltaOffset(r)
+		if err != nil {
+			break
+		}
+		if baseOffset <= 0 || baseOffset > offset {
+			err = fmt.Errorf("invalid delta base offset")
+			break
+		}
+		var base []byte
+		t, base, err = p.readObject(offset-baseOffset, store)
+		if err != nil {
+			break
+		}
+		content, err = readDelta(r, base, int(size))
+	case packRefDelta:
+		var baseHash Hash
+		if _, err = io.ReadFull(r, baseHash[:]); err != nil {
+			break
+		}
+		var base []byte
+		t, base, err = store.Object(baseHash)
+		if err != nil {
+			break
+		}
+		content, err = readDelta(r, base, int(size))
+	default:
+		err = fmt.Errorf("unknown packfile object type %d", packType)
+	}
+	if err != nil {
+		return UnknownObject, nil, fmt.Errorf("failed to read packfile entry at %d, %w", offset, err)
+	}
+
+	p.mu.Lock()
+	if len(p.cache) >= maxPackCacheEntries {
+		p.cache = make(map[int64]packObject)
+	}
+	p.cache[offset] = packObject{Type: t, Content: content}
+	p.mu.Unlock()
+
+	return t, content, nil
+}
+
+// Close closes the packfile.
+func (p *packFile) Close() error {
+	return p.file.Close()
+}
+
+// readPackEntryHeader reads the packfile entry type and inflated size.
+func readPackEntryHeader(r io.ByteReader) (packType byte, size uint64, err error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	packType = (c >> 4) & 0x7
+	size = uint64(c & 0x0f)
+	shift := uint(4)
+	for c&0x80 != 0 {
+		if c, err = r.ReadByte(); err != nil {
+			return 0, 0, err
+		}
+		size |= uint64(c&0x7f) << shift
+		shift += 7
+	}
+
+	return packType, size, nil
+}
+
+// readOfsDeltaOffset reads the negative offset of an offset delta's base object.
+func readOfsDeltaOffset(r io.ByteReader) (int64, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	offset := int64(c & 0x7f)
+	for c&0x80 != 0 {
+		if c, err = r.ReadByte(); err != nil {
+			return 0, err
+		}
+		offset = ((offset + 1) << 7) | int64(c&0x7f)
+	}
+
+	return offset, nil
+}
+
+// readDelta inflates the delta instructions from the reader and applies them to the base object content.
+func readDelta(r io.Reader, base []byte, sizeHint int) ([]byte, error) {
+	delta, err := readAllZlib(r, sizeHint)
+	if err != nil {
+		return nil, err
+	}
+	return applyDelta(base, delta)
+}
+
+func applyDelta(base, delta []byte) ([]byte, error) {
+	d := bytes.NewReader(delta)
+
+	srcSize, err := binary.ReadUvarint(d)
+	if err != nil {
+		return nil, fmt.Errorf("malformed delta source size, %w", err)
+	}
+	if int(srcSize) != len(base) {
+		return nil, fmt.Errorf("delta base size mismatch, expect %d, got %d", srcSize, len(base))
+	}
+
+	dstSize, err := binary.ReadUvarint(d)
+	if err != nil {
+		return nil, fmt.Errorf("malformed delta target size, %w", err)
+	}
+
+	out := make([]byte, 0, dstSize)
+	for {
+		op, err := d.ReadByte()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case op&0x80 != 0:
+			// Copy from base object
+			var offset, size uint32
+			for i := uint(0); i < 4; i++ {
+				if op&(1<<i) == 0 {
+					continue
+				}
+				b, err := d.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				offset |= uint32(b) << (8 * i)
+			}
+			for i := uint(0); i < 3; i++ {
+				if op&(1<<(4+i)) == 0 {
+					continue
+				}
+				b, err := d.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				size |= uint32(b) << (8 * i)
+			}
+			if size == 0 {
+				size = 0x10000
+			}
+			if uint64(offset)+uint64(size) > uint64(len(base)) {
+				return nil, fmt.Errorf("delta copy out of range")
+			}
+			out = append(out, base[offset:offset+size]...)
+		case op != 0:
+			// Insert literal data
+			start := len(out)
+			out = append(out, make([]byte, op)...)
+			if _, err := io.ReadFull(d, out[start:]); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("reserved delta instruction")
+		}
+	}
+
+	if uint64(len(out)) != dstSize {
+		return nil, fmt.Errorf("delta target size mismatch, expect %d, got %d", dstSize, len(out))
+	}
+
+	return out, nil
+}
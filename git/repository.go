@@ -0,0 +1,80 @@
+package git
+
+// Reader provides read access to the tags, trees, and changes of a Git repository.
+type Reader interface {
+	// Tags returns a slice of the repository's tag names.
+	Tags() ([]string, error)
+
+	// LsTree lists the files present in the tree-ish. An optional set of one or more paths can be provided to limit
+	// the output file paths.
+	LsTree(tree string, paths ...string) ([]string, error)
+
+	// Changes lists the files that have changed at the given paths between from and to commit-like references.
+	Changes(from, to string, paths ...string) ([]string, error)
+
+	// Changed lists the files that changed for a specific commit or tag.
+	Changed(commit string, paths ...string) ([]string, error)
+}
+
+// Writer provides the operations for committing and tagging changes to a Git repository.
+type Writer interface {
+	// Add adds working directory changes to the index, passing the provided arguments.
+	Add(args ...string) error
+
+	// Commit commits the staged contents using the provided message.
+	Commit(message string) error
+
+	// Tag creates the given annotated tag at the given commit with the provided message.
+	Tag(tag, message, commit string) error
+}
+
+// Repository provides read and write operations for a Git repository.
+type Repository interface {
+	Reader
+	Writer
+}
+
+// ExecRepository is a Repository that invokes the git binary for each operation.
+type ExecRepository struct {
+	path string
+}
+
+// NewExecRepository returns an ExecRepository for the repository located at path.
+func NewExecRepository(path string) *ExecRepository {
+	return &ExecRepository{path: path}
+}
+
+// Tags returns a slice of Git tags for the repository.
+func (r *ExecRepository) Tags() ([]string, error) {
+	return Tags(r.path)
+}
+
+// LsTree lists the files present in the tree-ish for the repository.
+func (r *ExecRepository) LsTree(tree string, paths ...string) ([]string, error) {
+	return LsTree(r.path, tree, paths...)
+}
+
+// Changes lists the files that have changed at the given paths between from and to commit-like references.
+func (r *ExecRepository) Changes(from, to string, paths ...string) ([]string, error) {
+	return Changes(r.path, from, to, paths...)
+}
+
+// Changed lists the files that changed for a specific commit or tag.
+func (r *ExecRepository) Changed(commit string, paths ...string) ([]string, error) {
+	return Changed(r.path, commit, paths...)
+}
+
+// Add invokes git-add passing the provided arguments.
+func (r *ExecRepository) Add(args ...string) error {
+	return Add(r.path, args...)
+}
+
+// Commit commits the staged contents using the provided message.
+func (r *ExecRepository) Commit(message string) error {
+	return Commit(r.path, message)
+}
+
+// Tag creates the given annotated tag at the given commit with the provided message.
+func (r *ExecRepository) Tag(tag, message, commit string) error {
+	return Tag(r.path, tag, message, commit)
+}
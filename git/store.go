@@ -0,0 +1,421 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ObjectStore provides access to the objects and references of a Git repository.
+type ObjectStore interface {
+	// Object returns the type and content of the object identified by the hash.
+	Object(h Hash) (ObjectType, []byte, error)
+
+	// Refs returns the repository references mapped to the object they resolve to. The map is keyed by the full
+	// reference name, (e.g. refs/tags/v1.2.3), and includes HEAD if present.
+	Refs() (map[string]Hash, error)
+}
+
+// ObjectNotFoundError is an error returned when an object is not present in an ObjectStore.
+type ObjectNotFoundError struct {
+	Hash Hash
+}
+
+// Error returns the error description
+func (e *ObjectNotFoundError) Error() string {
+	return fmt.Sprintf("object not found, %v", e.Hash)
+}
+
+// FileStore is an ObjectStore that reads the loose objects, packfiles, and references from a Git repository's
+// directory. Objects are read directly from the object database without invoking the git binary.
+type FileStore struct {
+	gitDir    string
+	commonDir string
+
+	objectDirs []string
+
+	packsOnce sync.Once
+	packs     []*packFile
+	packsErr  error
+}
+
+// NewFileStore returns a FileStore for the Git repository with the working tree, or bare repository, located at path.
+func NewFileStore(path string) (*FileStore, error) {
+	gitDir, err := findGitDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	commonDir := gitDir
+	if b, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(b))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := checkRepositoryFormat(filepath.Join(commonDir, "config")); err != nil {
+		return nil, err
+	}
+
+	objectDirs, err := readObjectDirs(filepath.Join(commonDir, "objects"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStore{
+		gitDir:     gitDir,
+		commonDir:  commonDir,
+		objectDirs: objectDirs,
+	}, nil
+}
+
+// findGitDir returns the Git directory for the repository at path. Path may be a bare repository, or a working tree
+// containing a .git directory or a .git file referring to the Git directory.
+func findGitDir(path string) (string, error) {
+	dotGit := filepath.Join(path, ".git")
+
+	fi, err := os.Stat(dotGit)
+	if err != nil && os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(path, "objects")); err != nil {
+			return "", fmt.Errorf("git repository not found, %v", path)
+		}
+		return path, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if fi.IsDir() {
+		return dotGit, nil
+	}
+
+	b, err := ioutil.ReadFile(dotGit)
+	if err != nil {
+		return "", err
+	}
+
+	const gitDirPrefix = "gitdir:"
+	v := strings.TrimSpace(string(b))
+	if !strings.HasPrefix(v, gitDirPrefix) {
+		return "", fmt.Errorf("invalid .git file, %v", dotGit)
+	}
+	gitDir := strings.TrimSpace(strings.TrimPrefix(v, gitDirPrefix))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+
+	return gitDir, nil
+}
+
+// checkRepositoryFormat returns an error if the repository's config enables an object format or reference storage
+// extension the FileStore can not read, (e.g. extensions.objectFormat=sha256 or extensions.refStorage=reftable), as
+// the repository's objects and references would otherwise silently not be found.
+func checkRepositoryFormat(configPath string) error {
+	f, err := os.Open(configPath)
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var section string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return fmt.Errorf("invalid git config section, %v", line)
+			}
+			// Section names are case-insensitive, subsections are not used by the extensions section.
+			section = strings.ToLower(strings.TrimSpace(line[1:end]))
+			continue
+		}
+		if section != "extensions" {
+			continue
+		}
+
+		key, value := line, ""
+		if i := strings.IndexByte(line, '='); i >= 0 {
+			key, value = line[:i], line[i+1:]
+		}
+		if i := strings.IndexAny(value, "#;"); i >= 0 {
+			value = value[:i]
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.Trim(strings.TrimSpace(value), `"`))
+
+		switch key {
+		case "objectformat":
+			if value != "sha1" {
+				return fmt.Errorf("unsupported git repository object format %v, only sha1 is supported", value)
+			}
+		case "refstorage":
+			if value != "files" {
+				return fmt.Errorf("unsupported git repository reference storage %v, only files is supported", value)
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// readObjectDirs returns the object directory, and any alternate object directories it refers to.
+func readObjectDirs(objectDir string) ([]string, error) {
+	dirs := []string{objectDir}
+
+	f, err := os.Open(filepath.Join(objectDir, "info", "alternates"))
+	if err != nil && os.IsNotExist(err) {
+		return dirs, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(objectDir, line)
+		}
+		dirs = append(dirs, line)
+	}
+
+	return dirs, scanner.Err()
+}
+
+// Object returns the type and content of the object identified by the hash.
+func (s *FileStore) Object(h Hash) (ObjectType, []byte, error) {
+	for _, dir := range s.objectDirs {
+		t, content, err := readLooseObject(dir, h)
+		if err == nil {
+			return t, content, nil
+		} else if !os.IsNotExist(err) {
+			return UnknownObject, nil, err
+		}
+	}
+
+	packs, err := s.loadPacks()
+	if err != nil {
+		return UnknownObject, nil, err
+	}
+
+	for _, pack := range packs {
+		offset, ok := pack.find(h)
+		if !ok {
+			continue
+		}
+		return pack.readObject(offset, s)
+	}
+
+	return UnknownObject, nil, &ObjectNotFoundError{Hash: h}
+}
+
+func (s *FileStore) loadPacks() ([]*packFile, error) {
+	s.packsOnce.Do(func() {
+		for _, dir := range s.objectDirs {
+			matches, err := filepath.Glob(filepath.Join(dir, "pack", "*.idx"))
+			if err != nil {
+				s.packsErr = err
+				return
+			}
+			for _, idxPath := range matches {
+				pack, err := openPackFile(idxPath)
+				if err != nil {
+					s.packsErr = fmt.Errorf("failed to open packfile, %v, %w", idxPath, err)
+					return
+				}
+				s.packs = append(s.packs, pack)
+			}
+		}
+	})
+	return s.packs, s.packsErr
+}
+
+// Close closes the packfiles opened by the store.
+func (s *FileStore) Close() error {
+	var err error
+	for _, pack := range s.packs {
+		if cErr := pack.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+func readLooseObject(objectDir string, h Hash) (ObjectType, []byte, error) {
+	name := h.String()
+
+	f, err := os.Open(filepath.Join(objectDir, name[:2], name[2:]))
+	if err != nil {
+		return UnknownObject, nil, err
+	}
+	defer f.Close()
+
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return UnknownObject, nil, fmt.Errorf("failed to read loose object %v, %w", name, err)
+	}
+	defer zr.Close()
+
+	b, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return UnknownObject, nil, fmt.Errorf("failed to read loose object %v, %w", name, err)
+	}
+
+	nul := bytes.IndexByte(b, 0)
+	if nul == -1 {
+		return UnknownObject, nil, fmt.Errorf("malformed loose object header, %v", name)
+	}
+	header := strings.SplitN(string(b[:nul]), " ", 2)
+	if len(header) != 2 {
+		return UnknownObject, nil, fmt.Errorf("malformed loose object header, %v", name)
+	}
+
+	t := parseObjectType(header[0])
+	if t == UnknownObject {
+		return UnknownObject, nil, fmt.Errorf("unknown loose object type %v, %v", header[0], name)
+	}
+
+	size, err := strconv.Atoi(header[1])
+	if err != nil || size != len(b)-nul-1 {
+		return UnknownObject, nil, fmt.Errorf("malformed loose object size, %v", name)
+	}
+
+	return t, b[nul+1:], nil
+}
+
+// Refs returns the repository references mapped to the object they resolve to.
+func (s *FileStore) Refs() (map[string]Hash, error) {
+	values := make(map[string]string)
+
+	if err := readPackedRefs(filepath.Join(s.commonDir, "packed-refs"), values); err != nil {
+		return nil, err
+	}
+
+	refsDir := filepath.Join(s.commonDir, "refs")
+	err := filepath.Walk(refsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil && os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.commonDir, path)
+		if err != nil {
+			return err
+		}
+		values[filepath.ToSlash(rel)] = strings.TrimSpace(string(b))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if b, err := ioutil.ReadFile(filepath.Join(s.gitDir, "HEAD")); err == nil {
+		values["HEAD"] = strings.TrimSpace(string(b))
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	refs := make(map[string]Hash, len(values))
+	for name := range values {
+		h, ok, err := resolveRefValue(values, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			refs[name] = h
+		}
+	}
+
+	return refs, nil
+}
+
+// resolveRefValue resolves the reference name to an object hash, following symbolic references. Returns false if
+// the reference is a symbolic reference to a reference that does not exist, (e.g. HEAD of an empty repository).
+func resolveRefValue(values map[string]string, name string) (Hash, bool, error) {
+	const symRefPrefix = "ref:"
+	const maxDepth = 5
+
+	for depth := 0; depth < maxDepth; depth++ {
+		v, ok := values[name]
+		if !ok {
+			return Hash{}, false, nil
+		}
+		if !strings.HasPrefix(v, symRefPrefix) {
+			h, err := ParseHash(v)
+			if err != nil {
+				return Hash{}, false, fmt.Errorf("invalid reference %v, %w", name, err)
+			}
+			return h, true, nil
+		}
+		name = strings.TrimSpace(strings.TrimPrefix(v, symRefPrefix))
+	}
+
+	return Hash{}, false, fmt.Errorf("symbolic reference nested too deeply, %v", name)
+}
+
+func readPackedRefs(path string, values map[string]string) error {
+	f, err := os.Open(path)
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Skip comments, and the peeled values of annotated tags.
+		if len(line) == 0 || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		split := strings.SplitN(line, " ", 2)
+		if len(split) != 2 {
+			return fmt.Errorf("malformed packed-refs line, %q", line)
+		}
+		values[split[1]] = split[0]
+	}
+
+	return scanner.Err()
+}
+
+// readAllZlib decompresses the zlib stream from the reader.
+func readAllZlib(r io.Reader, sizeHint int) ([]byte, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	buf := bytes.NewBuffer(make([]byte, 0, sizeHint))
+	if _, err := io.Copy(buf, zr); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFileStoreRepositoryFormat(t *testing.T) {
+	cases := map[string]struct {
+		Config    string
+		ExpectErr string
+	}{
+		"no config": {},
+		"default format": {
+			Config: "[core]\n\trepositoryformatversion = 0\n\tbare = false\n",
+		},
+		"sha1 object format": {
+			Config: "[core]\n\trepositoryformatversion = 1\n[extensions]\n\tobjectFormat = sha1\n",
+		},
+		"files reference storage": {
+			Config: "[core]\n\trepositoryformatversion = 1\n[Extensions]\n\trefStorage = files\n",
+		},
+		"sha256 object format": {
+			Config:    "[core]\n\trepositoryformatversion = 1\n[extensions]\n\tobjectformat = sha256\n",
+			ExpectErr: "unsupported git repository object format sha256",
+		},
+		"reftable reference storage": {
+			Config:    "[core]\n\trepositoryformatversion = 1\n; comment\n[extensions]\n\trefStorage = \"reftable\"\n",
+			ExpectErr: "unsupported git repository reference storage reftable",
+		},
+		"inline comment": {
+			Config: "[extensions]\n\tobjectFormat = sha1 # default\n",
+		},
+		"extension key in other section": {
+			Config: "[remote \"origin\"]\n\tobjectFormat = sha256\n",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			gitDir := filepath.Join(dir, ".git")
+			if err := os.MkdirAll(filepath.Join(gitDir, "objects"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.Config) > 0 {
+				if err := os.WriteFile(filepath.Join(gitDir, "config"), []byte(tt.Config), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			store, err := NewFileStore(dir)
+			if len(tt.ExpectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if err := store.Close(); err != nil {
+				t.Errorf("expect no error, got %v", err)
+			}
+		})
+	}
+}
@@ -7,3 +7,10 @@ func Tag(path, tag, message string, commit string) error {
 	_, err := Git(path, arguments...)
 	return err
 }
+
+// DeleteTag invokes git-tag in the specified path, deleting the given tag.
+func DeleteTag(path, tag string) error {
+	arguments := []string{"tag", "-d", tag}
+	_, err := Git(path, arguments...)
+	return err
+}
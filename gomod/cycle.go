@@ -0,0 +1,244 @@
+package gomod
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// RequireEdge is a go.mod require directive of one repository module for another.
+type RequireEdge struct {
+	// The module path of the requiring module.
+	From string
+
+	// The relative repository path of the requiring module.
+	FromRelativeRepoPath string
+
+	// The module path of the required module.
+	To string
+
+	// The require directive, nil if the go.mod does not contain one.
+	Require *modfile.Require
+}
+
+// String returns the go.mod file and line of the require directive, (e.g. "a/go.mod:5: require example.com/b v1.0.0").
+func (e RequireEdge) String() string {
+	file := path.Join(e.FromRelativeRepoPath, goModuleFile)
+	if e.Require == nil {
+		return fmt.Sprintf("%v: require %v", file, e.To)
+	}
+
+	line := fmt.Sprintf("require %v %v", e.Require.Mod.Path, e.Require.Mod.Version)
+	if e.Require.Syntax == nil {
+		return fmt.Sprintf("%v: %v", file, line)
+	}
+	return fmt.Sprintf("%v:%d: %v", file, e.Require.Syntax.Start.Line, line)
+}
+
+// DependencyCycle is a sequence of require directives between repository modules, where the last directive requires
+// the module of the first.
+type DependencyCycle []RequireEdge
+
+// Modules returns the module paths of the cycle, in require order.
+func (c DependencyCycle) Modules() []string {
+	modules := make([]string, 0, len(c))
+	for _, edge := range c {
+		modules = append(modules, edge.From)
+	}
+	return modules
+}
+
+// String returns the modules of the cycle followed by each require directive forming it.
+func (c DependencyCycle) String() string {
+	var sb strings.Builder
+
+	modules := c.Modules()
+	if len(modules) > 0 {
+		modules = append(modules, modules[0])
+	}
+	sb.WriteString(strings.Join(modules, " -> "))
+
+	for _, edge := range c {
+		sb.WriteString("\n\t")
+		sb.WriteString(edge.String())
+	}
+
+	return sb.String()
+}
+
+// DependencyCycleError is returned when the repository modules have require cycles.
+type DependencyCycleError struct {
+	Cycles []DependencyCycle
+}
+
+// Error returns each of the dependency cycles.
+func (e *DependencyCycleError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "found %d module dependency cycles", len(e.Cycles))
+	for _, cycle := range e.Cycles {
+		sb.WriteString("\n")
+		sb.WriteString(cycle.String())
+	}
+	return sb.String()
+}
+
+// Cycles returns the require cycles between the modules of the graph. Each group of modules that mutually depend on
+// each other, (a strongly connected component), is reported once, using the shortest cycle through the group's first
+// module path. Cycles are sorted by their first module path.
+func (g *ModuleGraph) Cycles() (cycles []DependencyCycle) {
+	for _, component := range g.stronglyConnectedComponents() {
+		members := make(map[string]struct{}, len(component))
+		for _, modulePath := range component {
+			members[modulePath] = struct{}{}
+		}
+
+		start := component[0]
+		if len(component) == 1 && !containsString(g.nodes[start].Requires, start) {
+			continue
+		}
+
+		cycles = append(cycles, g.shortestCycle(start, members))
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0].From < cycles[j][0].From
+	})
+
+	return cycles
+}
+
+// shortestCycle returns the shortest cycle from the start module back to itself, only traversing the member modules.
+func (g *ModuleGraph) shortestCycle(start string, members map[string]struct{}) DependencyCycle {
+	parents := make(map[string]string)
+
+	toVisit := []string{start}
+
+	var current string
+	for len(toVisit) > 0 {
+		current, toVisit = toVisit[0], toVisit[1:]
+
+		for _, require := range g.nodes[current].Requires {
+			if require == start {
+				return g.cycleEdges(start, current, parents)
+			}
+			if _, ok := members[require]; !ok {
+				continue
+			}
+			if _, ok := parents[require]; ok {
+				continue
+			}
+			parents[require] = current
+			toVisit = append(toVisit, require)
+		}
+	}
+
+	return nil
+}
+
+// cycleEdges returns the require edges of the cycle from start to last, following the parents, and back to start.
+func (g *ModuleGraph) cycleEdges(start, last string, parents map[string]string) DependencyCycle {
+	modules := []string{last}
+	for current := last; current != start; {
+		current = parents[current]
+		modules = append(modules, current)
+	}
+
+	// reverse to require order, start first
+	for i, j := 0, len(modules)-1; i < j; i, j = i+1, j-1 {
+		modules[i], modules[j] = modules[j], modules[i]
+	}
+
+	cycle := make(DependencyCycle, 0, len(modules))
+	for i, modulePath := range modules {
+		to := start
+		if i+1 < len(modules) {
+			to = modules[i+1]
+		}
+		cycle = append(cycle, g.requireEdge(modulePath, to))
+	}
+
+	return cycle
+}
+
+func (g *ModuleGraph) requireEdge(from, to string) RequireEdge {
+	node := g.nodes[from]
+
+	edge := RequireEdge{
+		From:                 from,
+		FromRelativeRepoPath: node.RelativeRepoPath,
+		To:                   to,
+	}
+	for _, require := range node.File.Require {
+		if require.Mod.Path == to {
+			edge.Require = require
+			break
+		}
+	}
+
+	return edge
+}
+
+// stronglyConnectedComponents returns the strongly connected components of the graph using Tarjan's algorithm. The
+// module paths of each component are sorted.
+func (g *ModuleGraph) stronglyConnectedComponents() (components [][]string) {
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+
+	var strongConnect func(modulePath string)
+	strongConnect = func(modulePath string) {
+		index[modulePath] = len(index)
+		lowLink[modulePath] = index[modulePath]
+		stack = append(stack, modulePath)
+		onStack[modulePath] = true
+
+		for _, require := range g.nodes[modulePath].Requires {
+			if _, ok := index[require]; !ok {
+				strongConnect(require)
+				if lowLink[require] < lowLink[modulePath] {
+					lowLink[modulePath] = lowLink[require]
+				}
+			} else if onStack[require] && index[require] < lowLink[modulePath] {
+				lowLink[modulePath] = index[require]
+			}
+		}
+
+		if lowLink[modulePath] != index[modulePath] {
+			return
+		}
+
+		var component []string
+		for {
+			member := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[member] = false
+			component = append(component, member)
+			if member == modulePath {
+				break
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+
+	for _, modulePath := range g.Modules() {
+		if _, ok := index[modulePath]; !ok {
+			strongConnect(modulePath)
+		}
+	}
+
+	return components
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModuleGraph_Cycles(t *testing.T) {
+	cases := map[string]struct {
+		Files  map[string]string
+		Expect []string
+	}{
+		"no cycles": {
+			Files: map[string]string{
+				"a": "module example.com/a\n\nrequire example.com/b v1.0.0\n",
+				"b": "module example.com/b\n",
+			},
+		},
+		"two module cycle": {
+			Files: map[string]string{
+				"a": "module example.com/a\n\nrequire example.com/b v1.0.0\n",
+				"b": "module example.com/b\n\ngo 1.16\n\nrequire example.com/a v1.2.0\n",
+				"c": "module example.com/c\n\nrequire example.com/a v1.0.0\n",
+			},
+			Expect: []string{
+				"example.com/a -> example.com/b -> example.com/a\n" +
+					"\ta/go.mod:3: require example.com/b v1.0.0\n" +
+					"\tb/go.mod:5: require example.com/a v1.2.0",
+			},
+		},
+		"shortest cycle of component": {
+			Files: map[string]string{
+				"a": "module example.com/a\n\nrequire (\n\texample.com/b v1.0.0\n\texample.com/c v1.0.0\n)\n",
+				"b": "module example.com/b\n\nrequire example.com/c v1.0.0\n",
+				"c": "module example.com/c\n\nrequire example.com/a v1.0.0\n",
+			},
+			Expect: []string{
+				"example.com/a -> example.com/c -> example.com/a\n" +
+					"\ta/go.mod:5: require example.com/c v1.0.0\n" +
+					"\tc/go.mod:3: require example.com/a v1.0.0",
+			},
+		},
+		"separate cycles and self require": {
+			Files: map[string]string{
+				"a": "module example.com/a\n\nrequire example.com/a v1.0.0\n",
+				"b": "module example.com/b\n\nrequire example.com/c v1.0.0\n",
+				"c": "module example.com/c\n\nrequire example.com/b v1.0.0\n",
+			},
+			Expect: []string{
+				"example.com/a -> example.com/a\n" +
+					"\ta/go.mod:3: require example.com/a v1.0.0",
+				"example.com/b -> example.com/c -> example.com/b\n" +
+					"\tb/go.mod:3: require example.com/c v1.0.0\n" +
+					"\tc/go.mod:3: require example.com/b v1.0.0",
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			graph := newTestModuleGraph(t, tt.Files)
+
+			var actual []string
+			for _, cycle := range graph.Cycles() {
+				actual = append(actual, cycle.String())
+			}
+
+			if diff := cmp.Diff(tt.Expect, actual); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
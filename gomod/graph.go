@@ -0,0 +1,192 @@
+package gomod
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/mod/modfile"
+)
+
+// GraphDirection is the direction a ModuleGraph is traversed in.
+type GraphDirection int
+
+const (
+	// DependenciesDirection traverses from a module to the modules it requires.
+	DependenciesDirection GraphDirection = iota
+
+	// DependentsDirection traverses from a module to the modules that require it.
+	DependentsDirection
+)
+
+// ModuleGraphNode is a module of a ModuleGraph.
+type ModuleGraphNode struct {
+	// The Go module path.
+	Path string
+
+	// The slash separated module directory relative to the repository root.
+	RelativeRepoPath string
+
+	// The module's go.mod file.
+	File *modfile.File
+
+	// The sorted module paths of the repository modules required by the module.
+	Requires []string
+}
+
+// ModuleGraph is the dependency graph of the modules within a repository. Only the require directives between
+// repository modules are edges of the graph, requirements of external modules are ignored.
+type ModuleGraph struct {
+	nodes      map[string]*ModuleGraphNode
+	dependents map[string][]string
+}
+
+// NewModuleGraph returns the dependency graph of the module files, keyed by the module's relative repository path.
+func NewModuleGraph(files map[string]*modfile.File) (*ModuleGraph, error) {
+	nodes := make(map[string]*ModuleGraphNode, len(files))
+	for relPath, file := range files {
+		modulePath, err := GetModulePath(file)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", relPath, err)
+		}
+		if existing, ok := nodes[modulePath]; ok {
+			return nil, fmt.Errorf("module %v is defined by both %v and %v", modulePath, existing.RelativeRepoPath, relPath)
+		}
+		nodes[modulePath] = &ModuleGraphNode{
+			Path:             modulePath,
+			RelativeRepoPath: relPath,
+			File:             file,
+		}
+	}
+
+	graph := &ModuleGraph{
+		nodes:      nodes,
+		dependents: make(map[string][]string),
+	}
+
+	for modulePath, node := range nodes {
+		for _, require := range node.File.Require {
+			if _, ok := nodes[require.Mod.Path]; !ok {
+				continue
+			}
+			node.Requires = append(node.Requires, require.Mod.Path)
+			graph.dependents[require.Mod.Path] = append(graph.dependents[require.Mod.Path], modulePath)
+		}
+		sort.Strings(node.Requires)
+	}
+
+	for _, dependents := range graph.dependents {
+		sort.Strings(dependents)
+	}
+
+	return graph, nil
+}
+
+// LoadModuleGraph loads the go.mod file of each module in the tree, returning the dependency graph of the modules.
+func LoadModuleGraph(tree *ModuleTree) (*ModuleGraph, error) {
+	files := make(map[string]*modfile.File)
+
+	for it := tree.Iterator(); ; {
+		module := it.Next()
+		if module == nil {
+			break
+		}
+
+		file, err := LoadModuleFile(module.AbsPath(), nil, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load module file: %w", err)
+		}
+		files[module.Path()] = file
+	}
+
+	return NewModuleGraph(files)
+}
+
+// Modules returns the sorted module paths of the graph.
+func (g *ModuleGraph) Modules() []string {
+	modules := make([]string, 0, len(g.nodes))
+	for modulePath := range g.nodes {
+		modules = append(modules, modulePath)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// Module returns the graph node of the module path. Returns false if the module is not in the graph.
+func (g *ModuleGraph) Module(modulePath string) (*ModuleGraphNode, bool) {
+	node, ok := g.nodes[modulePath]
+	return node, ok
+}
+
+// ModuleByRelativeRepoPath returns the graph node of the module at the relative repository path. Returns false if the
+// module is not in the graph.
+func (g *ModuleGraph) ModuleByRelativeRepoPath(relPath string) (*ModuleGraphNode, bool) {
+	for _, node := range g.nodes {
+		if node.RelativeRepoPath == relPath {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// Dependencies returns the sorted module paths of the repository modules directly required by the module.
+func (g *ModuleGraph) Dependencies(modulePath string) []string {
+	node, ok := g.nodes[modulePath]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), node.Requires...)
+}
+
+// Dependents returns the sorted module paths of the repository modules that directly require the module.
+func (g *ModuleGraph) Dependents(modulePath string) []string {
+	return append([]string(nil), g.dependents[modulePath]...)
+}
+
+// Subgraph returns the graph of the root modules and the modules reachable from them in the given direction. If depth
+// is greater than zero only modules within depth edges of a root module are included. Only the edges between modules
+// of the subgraph are kept.
+func (g *ModuleGraph) Subgraph(roots []string, direction GraphDirection, depth int) (*ModuleGraph, error) {
+	included := make(map[string]int)
+
+	var toVisit []string
+	for _, modulePath := range roots {
+		if _, ok := g.nodes[modulePath]; !ok {
+			return nil, fmt.Errorf("module not found: %v", modulePath)
+		}
+		if _, ok := included[modulePath]; !ok {
+			included[modulePath] = 0
+			toVisit = append(toVisit, modulePath)
+		}
+	}
+
+	var current string
+	for len(toVisit) > 0 {
+		current, toVisit = toVisit[0], toVisit[1:]
+
+		distance := included[current]
+		if depth > 0 && distance >= depth {
+			continue
+		}
+
+		next := g.Dependencies(current)
+		if direction == DependentsDirection {
+			next = g.Dependents(current)
+		}
+
+		for _, modulePath := range next {
+			if _, ok := included[modulePath]; ok {
+				continue
+			}
+			included[modulePath] = distance + 1
+			toVisit = append(toVisit, modulePath)
+		}
+	}
+
+	files := make(map[string]*modfile.File, len(included))
+	for modulePath := range included {
+		node := g.nodes[modulePath]
+		files[node.RelativeRepoPath] = node.File
+	}
+
+	return NewModuleGraph(files)
+}
@@ -0,0 +1,101 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/mod/modfile"
+)
+
+func newTestModuleGraph(t *testing.T, files map[string]string) *ModuleGraph {
+	t.Helper()
+
+	parsed := make(map[string]*modfile.File, len(files))
+	for relPath, content := range files {
+		file, err := modfile.Parse(relPath+"/go.mod", []byte(content), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed[relPath] = file
+	}
+
+	graph, err := NewModuleGraph(parsed)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return graph
+}
+
+func TestModuleGraph(t *testing.T) {
+	graph := newTestModuleGraph(t, map[string]string{
+		".":          "module example.com/m\n\nrequire example.com/ext v1.0.0\n",
+		"core":       "module example.com/m/core\n",
+		"service/a":  "module example.com/m/service/a\n\nrequire example.com/m/core v1.0.0\n",
+		"service/b":  "module example.com/m/service/b\n\nrequire (\n\texample.com/m/core v1.0.0\n\texample.com/m/service/a v1.0.0\n)\n",
+		"feature/cc": "module example.com/m/feature/cc\n\nrequire example.com/m/service/b v1.0.0\n",
+	})
+
+	if diff := cmp.Diff([]string{"example.com/m/core", "example.com/m/service/a"}, graph.Dependencies("example.com/m/service/b")); len(diff) > 0 {
+		t.Errorf("dependencies: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"example.com/m/service/a", "example.com/m/service/b"}, graph.Dependents("example.com/m/core")); len(diff) > 0 {
+		t.Errorf("dependents: %s", diff)
+	}
+	if diff := cmp.Diff([]string(nil), graph.Dependencies("example.com/m")); len(diff) > 0 {
+		t.Errorf("external dependencies: %s", diff)
+	}
+
+	cases := map[string]struct {
+		Roots     []string
+		Direction GraphDirection
+		Depth     int
+		Expect    []string
+	}{
+		"dependencies": {
+			Roots:     []string{"example.com/m/feature/cc"},
+			Direction: DependenciesDirection,
+			Expect: []string{
+				"example.com/m/core",
+				"example.com/m/feature/cc",
+				"example.com/m/service/a",
+				"example.com/m/service/b",
+			},
+		},
+		"dependents with depth": {
+			Roots:     []string{"example.com/m/core"},
+			Direction: DependentsDirection,
+			Depth:     1,
+			Expect: []string{
+				"example.com/m/core",
+				"example.com/m/service/a",
+				"example.com/m/service/b",
+			},
+		},
+		"dependents": {
+			Roots:     []string{"example.com/m/core"},
+			Direction: DependentsDirection,
+			Expect: []string{
+				"example.com/m/core",
+				"example.com/m/feature/cc",
+				"example.com/m/service/a",
+				"example.com/m/service/b",
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			subgraph, err := graph.Subgraph(tt.Roots, tt.Direction, tt.Depth)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.Expect, subgraph.Modules()); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := graph.Subgraph([]string{"example.com/unknown"}, DependentsDirection, 0); err == nil {
+		t.Error("expect error, got none")
+	}
+}
@@ -9,11 +9,32 @@ import (
 	"golang.org/x/mod/modfile"
 )
 
+// UpdateRequiresOptions are the options for UpdateRequires.
+type UpdateRequiresOptions struct {
+	// The major and minor version line, (e.g. v1.24), that the requires of
+	// repository modules are updated within, instead of the required module's
+	// latest tag.
+	VersionLine string
+
+	// The version lines of individual modules, keyed by the module's relative
+	// repository path, overriding VersionLine.
+	ModuleVersionLines map[string]string
+}
+
 // UpdateRequires updates all modules discovered starting at repoRootPath using
 // the provided tags and dependencies. Using force will update the module
 // required versions regardless whether the target version less the currently
 // written version.
-func UpdateRequires(repoRootPath string, tags git.ModuleTags, dependencies map[string]string, force bool) error {
+//
+// Requires of repository modules with a version line are updated to the
+// required module's latest tag within the version line, and are left unchanged
+// if the required module has no tag within the line.
+func UpdateRequires(repoRootPath string, tags git.ModuleTags, dependencies map[string]string, force bool, optFns ...func(o *UpdateRequiresOptions)) error {
+	var options UpdateRequiresOptions
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
 	discoverer := NewDiscoverer(repoRootPath)
 
 	if err := discoverer.Discover(); err != nil {
@@ -33,7 +54,7 @@ func UpdateRequires(repoRootPath string, tags git.ModuleTags, dependencies map[s
 			break
 		}
 
-		mod, err := LoadModuleFile(module.Path(), nil, true)
+		mod, err := LoadModuleFile(module.AbsPath(), nil, true)
 		if err != nil {
 			return fmt.Errorf("failed to load module file: %w", err)
 		}
@@ -47,7 +68,11 @@ func UpdateRequires(repoRootPath string, tags git.ModuleTags, dependencies map[s
 		for _, require := range mod.File.Require {
 			version := require.Mod.Version
 			if requireMod, ok := repoModules[require.Mod.Path]; ok {
-				latest, ok := tags.Latest(requireMod.ModuleDir)
+				tagPath := git.ModuleTagPath(requireMod.ModuleDir, require.Mod.Path)
+				latest, ok := tags.Latest(tagPath)
+				if line := options.versionLine(requireMod.ModuleDir); len(line) > 0 {
+					latest, ok = tags.LatestInLine(tagPath, line)
+				}
 				if ok {
 					if force {
 						version = latest
@@ -77,3 +102,12 @@ func UpdateRequires(repoRootPath string, tags git.ModuleTags, dependencies map[s
 
 	return nil
 }
+
+// versionLine returns the version line of the module at the relative
+// repository path, or empty if the module has none.
+func (o UpdateRequiresOptions) versionLine(relPath string) string {
+	if line, ok := o.ModuleVersionLines[relPath]; ok && len(line) > 0 {
+		return line
+	}
+	return o.VersionLine
+}
@@ -0,0 +1,76 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
+)
+
+func TestUpdateRequiresVersionLine(t *testing.T) {
+	tags := git.ParseModuleTags([]string{"a/v1.24.0", "a/v1.24.2", "a/v1.25.0", "a/v1.26.1"})
+
+	cases := map[string]struct {
+		Options func(o *UpdateRequiresOptions)
+		Expect  string
+	}{
+		"latest": {
+			Options: func(o *UpdateRequiresOptions) {},
+			Expect:  "v1.26.1",
+		},
+		"version line": {
+			Options: func(o *UpdateRequiresOptions) {
+				o.VersionLine = "v1.24"
+			},
+			Expect: "v1.24.2",
+		},
+		"module version line": {
+			Options: func(o *UpdateRequiresOptions) {
+				o.VersionLine = "v1.24"
+				o.ModuleVersionLines = map[string]string{"a": "v1.25"}
+			},
+			Expect: "v1.25.0",
+		},
+		"no tag in version line": {
+			Options: func(o *UpdateRequiresOptions) {
+				o.VersionLine = "v1.23"
+			},
+			Expect: "v1.24.0",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			rootDir := t.TempDir()
+			files := map[string]string{
+				"a/go.mod": "module example.com/repo/a\n",
+				"b/go.mod": "module example.com/repo/b\n\nrequire example.com/repo/a v1.24.0\n",
+			}
+			for name, content := range files {
+				p := filepath.Join(rootDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := UpdateRequires(rootDir, tags, nil, false, tt.Options); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			file, err := LoadModuleFile(filepath.Join(rootDir, "b"), nil, true)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if len(file.Require) != 1 {
+				t.Fatalf("expect 1 require, got %v", len(file.Require))
+			}
+			if e, a := tt.Expect, file.Require[0].Mod.Version; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -2,15 +2,17 @@ package release
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
+	"sync"
 
 	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+	"golang.org/x/mod/modfile"
 )
 
 // ModuleFinder is a type that searches for modules
@@ -24,12 +26,93 @@ type ModuleFinder interface {
 
 const tombstonedModuleAttrib = "tombstone"
 
+// CalculateOptions provides options for Calculate.
+type CalculateOptions struct {
+	// The Git repository used to determine module changes. Defaults to
+	// invoking the git binary for the finder's root directory.
+	Repository git.Reader
+
+	// The maximum number of modules whose changes are determined
+	// concurrently. Defaults to the number of CPUs.
+	Concurrency int
+
+	// Relative repository path patterns, (see path.Match), of the modules
+	// to release. Defaults to all modules.
+	Include []string
+
+	// Relative repository path patterns, (see path.Match), of the modules
+	// not to release.
+	Exclude []string
+
+	// The major and minor version line, (e.g. v1.24), all modules are
+	// released from, unless overridden by the module's configuration.
+	// See repotools.ModuleConfig.VersionLine.
+	VersionLine string
+}
+
+// moduleCheck is the state of a module whose changes are being determined.
+type moduleCheck struct {
+	module     *gomod.ModuleTreeNode
+	moduleFile *modfile.File
+	modulePath string
+	config     repotools.ModuleConfig
+
+	latestVersion string
+	startTag      string
+
+	// The module has no release in the repository wide version line.
+	outOfVersionLine bool
+
+	hasChanges bool
+	changes    []string
+	carvedOut  []string
+	err        error
+}
+
 // Calculate calculates the modules to be released and their next versions
 // based on the Git history, previous tags, module configuration, and
 // associated changelog annotations.
-func Calculate(finder ModuleFinder, tags git.ModuleTags, config repotools.Config, annotations []changelog.Annotation) (map[string]*Module, error) {
+//
+// The Git changes of each module are determined concurrently, bounded by
+// CalculateOptions.Concurrency. The result does not depend on the order the
+// modules are checked in.
+//
+// If CalculateOptions.Include or CalculateOptions.Exclude are set, the changes
+// of modules that are not selected, and are not a changed dependency of a
+// selected module, are moved to the module's DeferredChanges after dependency
+// updates are determined.
+//
+// A gomod.DependencyCycleError is returned if the modules have require cycles
+// between each other, as the order the modules are released in would be
+// ambiguous.
+//
+// Modules with a version line, set by CalculateOptions.VersionLine or the
+// module's configuration, have their changes determined from the latest tag
+// within the version line instead of the module's latest tag. Modules with no
+// release within the CalculateOptions.VersionLine are not released, and any
+// dependency updates are moved to the module's DeferredChanges.
+func Calculate(finder ModuleFinder, tags git.ModuleTags, config repotools.Config, annotations []changelog.Annotation, optFns ...func(o *CalculateOptions)) (map[string]*Module, error) {
 	rootDir := finder.Root()
 
+	options := CalculateOptions{
+		Concurrency: runtime.NumCPU(),
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if options.Repository == nil {
+		options.Repository = git.NewExecRepository(rootDir)
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+	if err := validateModulePatterns(options.Include); err != nil {
+		return nil, err
+	}
+	if err := validateModulePatterns(options.Exclude); err != nil {
+		return nil, err
+	}
+
 	repositoryModules := finder.Modules()
 
 	moduleAnnotations := make(map[string][]changelog.Annotation)
@@ -48,17 +131,13 @@ func Calculate(finder ModuleFinder, tags git.ModuleTags, config repotools.Config
 		}
 	}
 
-	checkedModules := map[string]*Module{}
+	var checks []*moduleCheck
 	for it := repositoryModules.Iterator(); ; {
 		module := it.Next()
 		if module == nil {
 			break
 		}
 
-		var latestVersion string
-		var hasChanges bool
-		var changes []string
-
 		// Tombstone modules must have no files, (excludes submodules).
 		if module.HasAttribute(tombstonedModuleAttrib) {
 			files, err := listRelFiles(rootDir, module.AbsPath())
@@ -86,92 +165,192 @@ func Calculate(finder ModuleFinder, tags git.ModuleTags, config repotools.Config
 			return nil, fmt.Errorf("failed to read module path: %w", err)
 		}
 
-		latestVersion, ok := tags.Latest(module.Path())
-		if ok {
-			startTag, err := git.ToModuleTag(module.Path(), latestVersion)
-			if err != nil {
-				log.Fatalf("failed to convert module path and version to tag: %v", err)
-			}
+		check := &moduleCheck{
+			module:     module,
+			moduleFile: moduleFile,
+			modulePath: modulePath,
+			config:     config.Modules[module.Path()],
+		}
 
-			changes, err = git.Changes(finder.Root(), startTag, "HEAD", module.Path())
+		if len(check.config.VersionLine) == 0 {
+			check.config.VersionLine = options.VersionLine
+		}
+		if len(check.config.VersionLine) > 0 {
+			check.config.VersionLine, err = ParseVersionLine(check.config.VersionLine)
 			if err != nil {
-				log.Fatalf("failed to get git changes: %v", err)
+				return nil, fmt.Errorf("%v: %w", module.Path(), err)
 			}
+		}
 
-			// Only consider changes that are specific to this module. Other
-			// module changes will be considered separately.
-			changes, err = gomod.FilterModuleFiles(module, changes)
+		tagPath := git.ModuleTagPath(module.Path(), modulePath)
+		latestVersion, ok := tags.Latest(tagPath)
+		if len(check.config.VersionLine) > 0 {
+			latestVersion, ok = tags.LatestInLine(tagPath, check.config.VersionLine)
+			check.outOfVersionLine = !ok && len(config.Modules[module.Path()].VersionLine) == 0
+		}
+		if ok {
+			check.latestVersion = latestVersion
+			check.startTag, err = git.ToModuleTag(tagPath, latestVersion)
 			if err != nil {
-				return nil, fmt.Errorf("failed to determine module changes: %w", err)
-			}
-			hasChanges = len(changes) != 0
-
-			if !hasChanges {
-				// Check if any of the submodules have been "carved out" of
-				// this module since the last tagged release
-				for it := module.Iterator(); ; {
-					subModule := it.Next()
-					if subModule == nil {
-						break
-					}
-
-					// Ignore Tombstoned modules, since they no longer exist locally.
-					if module.HasAttribute(tombstonedModuleAttrib) {
-						continue
-					}
-
-					// Is an existing submodule?
-					//  - yes, skip existing modules
-					//  - no, check if new modules is a carve out
-					if _, ok := tags.Latest(subModule.Path()); ok {
-						continue
-					}
-
-					// Did parent module contain this path previously in its tree?
-					treeFiles, err := git.LsTree(rootDir, startTag, subModule.Path())
-					if err != nil {
-						return nil, fmt.Errorf("failed to list git tree: %v", err)
-					}
-
-					carvedOut, err := isModuleCarvedOut(subModule, treeFiles)
-					if err != nil {
-						return nil, err
-					}
-					if carvedOut {
-						hasChanges = true
-						break
-					}
-				}
+				return nil, fmt.Errorf("failed to convert module path and version to tag: %w", err)
 			}
 		}
 
+		checks = append(checks, check)
+	}
+
+	checkModuleChanges(options, tags, checks)
+
+	checkedModules := map[string]*Module{}
+	for _, check := range checks {
+		if check.err != nil {
+			return nil, check.err
+		}
+
 		var changeReason ModuleChange
-		if hasChanges && len(latestVersion) > 0 {
+		if check.hasChanges && len(check.latestVersion) > 0 {
 			// Has changes and is an existing module
 			changeReason |= SourceChange
-		} else if len(latestVersion) == 0 {
+		} else if len(check.latestVersion) == 0 && !check.outOfVersionLine {
 			// New module with changes.
 			changeReason |= NewModule
 		}
 
-		checkedModules[modulePath] = &Module{
-			File:              moduleFile,
+		module := check.module
+		checkedModules[check.modulePath] = &Module{
+			File:              check.moduleFile,
 			RelativeRepoPath:  module.Path(),
-			Latest:            latestVersion,
+			Latest:            check.latestVersion,
 			Changes:           changeReason,
-			FileChanges:       changes,
+			FileChanges:       check.changes,
+			CarvedOutModules:  check.carvedOut,
 			ChangeAnnotations: moduleAnnotations[module.Path()],
-			ModuleConfig:      config.Modules[module.Path()],
+			ModuleConfig:      check.config,
 		}
 	}
 
-	if err := CalculateDependencyUpdates(checkedModules); err != nil {
+	graph, err := newModuleGraph(checkedModules)
+	if err != nil {
 		return nil, err
 	}
 
+	if cycles := graph.Cycles(); len(cycles) > 0 {
+		return nil, &gomod.DependencyCycleError{Cycles: cycles}
+	}
+
+	if err := calculateDependencyUpdates(checkedModules, graph); err != nil {
+		return nil, err
+	}
+
+	if len(options.Include) > 0 || len(options.Exclude) > 0 {
+		selected, err := selectModules(checkedModules, graph, options.Include, options.Exclude)
+		if err != nil {
+			return nil, err
+		}
+		deferUnselectedModules(checkedModules, selected)
+	}
+
+	for _, check := range checks {
+		if !check.outOfVersionLine {
+			continue
+		}
+		module := checkedModules[check.modulePath]
+		module.DeferredChanges |= module.Changes
+		module.Changes = 0
+	}
+
 	return checkedModules, nil
 }
 
+// checkModuleChanges determines the changes of each previously tagged module
+// using a bounded pool of workers. Each check's result is recorded on the
+// check itself, so the outcome is independent of scheduling order.
+func checkModuleChanges(options CalculateOptions, tags git.ModuleTags, checks []*moduleCheck) {
+	jobs := make(chan *moduleCheck)
+
+	var wg sync.WaitGroup
+	wg.Add(options.Concurrency)
+	for i := 0; i < options.Concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for check := range jobs {
+				check.hasChanges, check.changes, check.carvedOut, check.err = checkModule(options.Repository, tags, check)
+			}
+		}()
+	}
+
+	for _, check := range checks {
+		if len(check.startTag) == 0 {
+			continue
+		}
+		jobs <- check
+	}
+	close(jobs)
+
+	wg.Wait()
+}
+
+// checkModule returns the files changed within the module since its latest
+// tagged release, the submodules carved out of the module if it has no file
+// changes, and if the module has changes.
+func checkModule(repository git.Reader, tags git.ModuleTags, check *moduleCheck) (bool, []string, []string, error) {
+	module := check.module
+
+	changes, err := repository.Changes(check.startTag, "HEAD", module.Path())
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("failed to get git changes: %w", err)
+	}
+
+	// Only consider changes that are specific to this module. Other
+	// module changes will be considered separately.
+	changes, err = gomod.FilterModuleFiles(module, changes)
+	if err != nil {
+		return false, nil, nil, fmt.Errorf("failed to determine module changes: %w", err)
+	}
+	if len(changes) != 0 {
+		return true, changes, nil, nil
+	}
+
+	var carvedOutModules []string
+
+	// Check if any of the submodules have been "carved out" of
+	// this module since the last tagged release
+	for it := module.Iterator(); ; {
+		subModule := it.Next()
+		if subModule == nil {
+			break
+		}
+
+		// Ignore Tombstoned modules, since they no longer exist locally.
+		if module.HasAttribute(tombstonedModuleAttrib) {
+			continue
+		}
+
+		// Is an existing submodule?
+		//  - yes, skip existing modules
+		//  - no, check if new modules is a carve out
+		if _, ok := tags.Latest(subModule.Path()); ok {
+			continue
+		}
+
+		// Did parent module contain this path previously in its tree?
+		treeFiles, err := repository.LsTree(check.startTag, subModule.Path())
+		if err != nil {
+			return false, nil, nil, fmt.Errorf("failed to list git tree: %v", err)
+		}
+
+		carvedOut, err := isModuleCarvedOut(subModule, treeFiles)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		if carvedOut {
+			carvedOutModules = append(carvedOutModules, subModule.Path())
+		}
+	}
+
+	return len(carvedOutModules) > 0, changes, carvedOutModules, nil
+}
+
 // isModuleCarvedOut takes a list of files for a (new) submodule directory. The
 // list of files are the files that are located in the submodule directory path
 // from the parent's previous tagged release. Returns true the new submodule
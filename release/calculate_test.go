@@ -1,9 +1,16 @@
 package release
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
 	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+	"github.com/google/go-cmp/cmp"
 )
 
 func Test_isModuleCarvedOut1(t *testing.T) {
@@ -128,3 +135,276 @@ func Test_isModuleCarvedOut1(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculate(t *testing.T) {
+	released := map[string]string{
+		"go.mod":       "module example.com/repo\n",
+		"root.go":      "package repo\n",
+		"a/go.mod":     "module example.com/repo/a\n",
+		"a/a.go":       "package a\n",
+		"a/sub/sub.go": "package sub\n",
+		"b/go.mod":     "module example.com/repo/b\n",
+		"b/b.go":       "package b\n",
+		"d/go.mod":     "module example.com/repo/d\n",
+		"d/d.go":       "package d\n",
+	}
+	head := map[string]string{
+		"a/sub/go.mod": "module example.com/repo/a/sub\n",
+		"b/b.go":       "package b\n\n// Updated\n",
+		"c/go.mod":     "module example.com/repo/c\n",
+		"c/c.go":       "package c\n",
+	}
+	for name, content := range released {
+		if _, ok := head[name]; !ok {
+			head[name] = content
+		}
+	}
+
+	store := git.NewMemoryStore()
+	first, err := store.WriteCommit(released, "release")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	second, err := store.WriteCommit(head, "changes", first)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	store.SetRef("HEAD", second)
+
+	var tags []string
+	for _, tag := range []string{"v1.0.0", "a/v1.0.0", "b/v1.0.0", "d/v1.0.0"} {
+		store.WriteTag(tag, first, "release")
+		tags = append(tags, tag)
+	}
+
+	rootDir := t.TempDir()
+	for name, content := range head {
+		p := filepath.Join(rootDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type moduleResult struct {
+		RelativeRepoPath string
+		Latest           string
+		Changes          ModuleChange
+		FileChanges      []string
+		CarvedOutModules []string
+	}
+
+	expect := map[string]moduleResult{
+		"example.com/repo": {
+			RelativeRepoPath: ".",
+			Latest:           "v1.0.0",
+			Changes:          SourceChange,
+			CarvedOutModules: []string{"a/sub"},
+		},
+		"example.com/repo/a": {
+			RelativeRepoPath: "a",
+			Latest:           "v1.0.0",
+			Changes:          SourceChange,
+			CarvedOutModules: []string{"a/sub"},
+		},
+		"example.com/repo/a/sub": {
+			RelativeRepoPath: "a/sub",
+			Changes:          NewModule,
+		},
+		"example.com/repo/b": {
+			RelativeRepoPath: "b",
+			Latest:           "v1.0.0",
+			Changes:          SourceChange,
+			FileChanges:      []string{"b/b.go"},
+		},
+		"example.com/repo/c": {
+			RelativeRepoPath: "c",
+			Changes:          NewModule,
+		},
+		"example.com/repo/d": {
+			RelativeRepoPath: "d",
+			Latest:           "v1.0.0",
+		},
+	}
+
+	for _, concurrency := range []int{1, 2, 8} {
+		t.Run(strconv.Itoa(concurrency), func(t *testing.T) {
+			discoverer := gomod.NewDiscoverer(rootDir)
+			if err := discoverer.Discover(); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			modules, err := Calculate(discoverer, git.ParseModuleTags(tags), repotools.Config{}, nil, func(o *CalculateOptions) {
+				o.Repository = git.NewObjectRepository(store)
+				o.Concurrency = concurrency
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			actual := make(map[string]moduleResult, len(modules))
+			for modulePath, module := range modules {
+				var fileChanges []string
+				if len(module.FileChanges) > 0 {
+					fileChanges = module.FileChanges
+				}
+				actual[modulePath] = moduleResult{
+					RelativeRepoPath: module.RelativeRepoPath,
+					Latest:           module.Latest,
+					Changes:          module.Changes,
+					FileChanges:      fileChanges,
+					CarvedOutModules: module.CarvedOutModules,
+				}
+			}
+
+			if diff := cmp.Diff(expect, actual); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCalculateDependencyCycle(t *testing.T) {
+	files := map[string]string{
+		"a/go.mod": "module example.com/repo/a\n\nrequire example.com/repo/b v1.0.0\n",
+		"a/a.go":   "package a\n",
+		"b/go.mod": "module example.com/repo/b\n\nrequire example.com/repo/c v1.0.0\n",
+		"b/b.go":   "package b\n",
+		"c/go.mod": "module example.com/repo/c\n\nrequire example.com/repo/a v1.0.0\n",
+		"c/c.go":   "package c\n",
+	}
+
+	store := git.NewMemoryStore()
+	commit, err := store.WriteCommit(files, "initial")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	store.SetRef("HEAD", commit)
+
+	rootDir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(rootDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	discoverer := gomod.NewDiscoverer(rootDir)
+	if err := discoverer.Discover(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, err = Calculate(discoverer, git.ModuleTags{}, repotools.Config{}, nil, func(o *CalculateOptions) {
+		o.Repository = git.NewObjectRepository(store)
+	})
+	var cycleErr *gomod.DependencyCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expect dependency cycle error, got %v", err)
+	}
+
+	expect := [][]string{{"example.com/repo/a", "example.com/repo/b", "example.com/repo/c"}}
+	var actual [][]string
+	for _, cycle := range cycleErr.Cycles {
+		actual = append(actual, cycle.Modules())
+	}
+	if diff := cmp.Diff(expect, actual); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
+
+func TestCalculateVersionLine(t *testing.T) {
+	branched := map[string]string{
+		"a/go.mod": "module example.com/repo/a\n",
+		"a/a.go":   "package a\n",
+	}
+	mainline := map[string]string{
+		"a/go.mod": "module example.com/repo/a\n",
+		"a/a.go":   "package a\n",
+		"b/go.mod": "module example.com/repo/b\n\nrequire example.com/repo/a v1.26.0\n",
+		"b/b.go":   "package b\n",
+	}
+	head := map[string]string{
+		"a/go.mod": "module example.com/repo/a\n",
+		"a/a.go":   "package a\n\n// Fixed\n",
+		"b/go.mod": "module example.com/repo/b\n\nrequire example.com/repo/a v1.26.0\n",
+		"b/b.go":   "package b\n\n// Fixed\n",
+	}
+
+	store := git.NewMemoryStore()
+	first, err := store.WriteCommit(branched, "v1.24 release")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	second, err := store.WriteCommit(mainline, "v1.26 release", first)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	third, err := store.WriteCommit(head, "fixes", second)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	store.SetRef("HEAD", third)
+
+	store.WriteTag("a/v1.24.0", first, "release")
+	store.WriteTag("a/v1.26.0", second, "release")
+	store.WriteTag("b/v1.26.0", second, "release")
+	tags := git.ParseModuleTags([]string{"a/v1.24.0", "a/v1.26.0", "b/v1.26.0"})
+
+	rootDir := t.TempDir()
+	for name, content := range head {
+		p := filepath.Join(rootDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	discoverer := gomod.NewDiscoverer(rootDir)
+	if err := discoverer.Discover(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	modules, err := Calculate(discoverer, tags, repotools.Config{}, nil, func(o *CalculateOptions) {
+		o.Repository = git.NewObjectRepository(store)
+		o.VersionLine = "v1.24"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	type moduleResult struct {
+		Latest          string
+		Changes         ModuleChange
+		DeferredChanges ModuleChange
+	}
+
+	expect := map[string]moduleResult{
+		"example.com/repo/a": {
+			Latest:  "v1.24.0",
+			Changes: SourceChange,
+		},
+		"example.com/repo/b": {
+			DeferredChanges: DependencyUpdate,
+		},
+	}
+
+	actual := make(map[string]moduleResult, len(modules))
+	for modulePath, module := range modules {
+		actual[modulePath] = moduleResult{
+			Latest:          module.Latest,
+			Changes:         module.Changes,
+			DeferredChanges: module.DeferredChanges,
+		}
+	}
+
+	if diff := cmp.Diff(expect, actual); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
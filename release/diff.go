@@ -0,0 +1,131 @@
+package release
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ManifestDiff describes the module differences between two release manifests. Modules are identified by their
+// relative repository path, and are sorted by that path.
+type ManifestDiff struct {
+	// Modules present in the new manifest, but not the old manifest.
+	Added []string `json:"added,omitempty"`
+
+	// Modules present in the old manifest, but not the new manifest.
+	Removed []string `json:"removed,omitempty"`
+
+	// Modules present in both manifests whose next version or annotations differ.
+	Changed []ModuleManifestChange `json:"changed,omitempty"`
+}
+
+// ModuleManifestChange describes how a module's release differs between two release manifests.
+type ModuleManifestChange struct {
+	RelativeRepoPath string `json:"relative_repo_path"`
+
+	FromVersion string `json:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty"`
+
+	AddedAnnotations   []string `json:"added_annotations,omitempty"`
+	RemovedAnnotations []string `json:"removed_annotations,omitempty"`
+}
+
+// IsEmpty returns whether the manifests have no module differences.
+func (d ManifestDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffManifests compares the modules of the old and new release manifests. A module is changed if its To version
+// or annotations differ between the manifests.
+func DiffManifests(oldManifest, newManifest Manifest) (diff ManifestDiff) {
+	for _, relPath := range sortedModulePaths(newManifest) {
+		if _, ok := oldManifest.Modules[relPath]; !ok {
+			diff.Added = append(diff.Added, relPath)
+		}
+	}
+
+	for _, relPath := range sortedModulePaths(oldManifest) {
+		oldModule := oldManifest.Modules[relPath]
+		newModule, ok := newManifest.Modules[relPath]
+		if !ok {
+			diff.Removed = append(diff.Removed, relPath)
+			continue
+		}
+
+		change := ModuleManifestChange{
+			RelativeRepoPath:   relPath,
+			AddedAnnotations:   subtractStrings(newModule.Annotations, oldModule.Annotations),
+			RemovedAnnotations: subtractStrings(oldModule.Annotations, newModule.Annotations),
+		}
+		if oldModule.To != newModule.To {
+			change.FromVersion = oldModule.To
+			change.ToVersion = newModule.To
+		}
+
+		if len(change.ToVersion) == 0 && len(change.AddedAnnotations) == 0 && len(change.RemovedAnnotations) == 0 {
+			continue
+		}
+		diff.Changed = append(diff.Changed, change)
+	}
+
+	return diff
+}
+
+// MergeManifests combines the modules and tags of the release manifests into a single manifest with the given
+// release id. The manifests must not have any modules in common, and must have been calculated within the same version
+// line. The merged manifest is created with a release tag if any of the manifests were.
+func MergeManifests(id string, manifests ...Manifest) (Manifest, error) {
+	merged := Manifest{
+		SchemaVersion: ManifestSchemaVersion,
+		ID:            id,
+		Modules:       make(map[string]ModuleManifest),
+	}
+
+	for i, manifest := range manifests {
+		if i == 0 {
+			merged.VersionLine = manifest.VersionLine
+		} else if manifest.VersionLine != merged.VersionLine {
+			return Manifest{}, fmt.Errorf("version line %q of manifest %d, %v, does not match version line %q",
+				manifest.VersionLine, i+1, manifest.ID, merged.VersionLine)
+		}
+
+		for relPath, mm := range manifest.Modules {
+			if _, ok := merged.Modules[relPath]; ok {
+				return Manifest{}, fmt.Errorf("module %v is present in more than one manifest, (manifest %d, %v)",
+					relPath, i+1, manifest.ID)
+			}
+			merged.Modules[relPath] = mm
+		}
+		merged.Tags = append(merged.Tags, manifest.Tags...)
+		merged.WithReleaseTag = merged.WithReleaseTag || manifest.WithReleaseTag
+	}
+	sort.Strings(merged.Tags)
+
+	if err := merged.Validate(); err != nil {
+		return Manifest{}, fmt.Errorf("invalid merged manifest, %w", err)
+	}
+
+	return merged, nil
+}
+
+func sortedModulePaths(manifest Manifest) []string {
+	relPaths := make([]string, 0, len(manifest.Modules))
+	for relPath := range manifest.Modules {
+		relPaths = append(relPaths, relPath)
+	}
+	sort.Strings(relPaths)
+	return relPaths
+}
+
+// subtractStrings returns the values of a that are not in b, in the order they appear in a.
+func subtractStrings(a, b []string) (values []string) {
+	exclude := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		exclude[v] = struct{}{}
+	}
+	for _, v := range a {
+		if _, ok := exclude[v]; !ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
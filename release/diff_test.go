@@ -0,0 +1,181 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffManifests(t *testing.T) {
+	oldManifest := Manifest{
+		Modules: map[string]ModuleManifest{
+			".":          {ModulePath: "github.com/aws/aws-sdk-go-v2", From: "v1.2.3", To: "v1.2.4"},
+			"config":     {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.0.0", To: "v1.0.1", Annotations: []string{"a", "b"}},
+			"credential": {ModulePath: "github.com/aws/aws-sdk-go-v2/credential", From: "v1.0.0", To: "v1.1.0"},
+			"service/s3": {ModulePath: "github.com/aws/aws-sdk-go-v2/service/s3", From: "v1.0.0", To: "v1.0.1"},
+		},
+	}
+	newManifest := Manifest{
+		Modules: map[string]ModuleManifest{
+			".":          {ModulePath: "github.com/aws/aws-sdk-go-v2", From: "v1.2.3", To: "v1.2.4"},
+			"config":     {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.0.0", To: "v1.0.1", Annotations: []string{"b", "c"}},
+			"credential": {ModulePath: "github.com/aws/aws-sdk-go-v2/credential", From: "v1.0.0", To: "v1.1.0-preview"},
+			"feature/s3": {ModulePath: "github.com/aws/aws-sdk-go-v2/feature/s3", To: "v1.0.0-preview"},
+		},
+	}
+
+	expect := ManifestDiff{
+		Added:   []string{"feature/s3"},
+		Removed: []string{"service/s3"},
+		Changed: []ModuleManifestChange{
+			{
+				RelativeRepoPath:   "config",
+				AddedAnnotations:   []string{"c"},
+				RemovedAnnotations: []string{"a"},
+			},
+			{
+				RelativeRepoPath: "credential",
+				FromVersion:      "v1.1.0",
+				ToVersion:        "v1.1.0-preview",
+			},
+		},
+	}
+
+	diff := DiffManifests(oldManifest, newManifest)
+	if d := cmp.Diff(expect, diff); len(d) > 0 {
+		t.Error(d)
+	}
+
+	if diff := DiffManifests(newManifest, newManifest); !diff.IsEmpty() {
+		t.Errorf("expect no differences, got %v", diff)
+	}
+}
+
+func TestMergeManifests(t *testing.T) {
+	cases := map[string]struct {
+		Manifests []Manifest
+		Expect    Manifest
+		ExpectErr string
+	}{
+		"non-overlapping": {
+			Manifests: []Manifest{
+				{
+					ID:             "2021-10-27",
+					WithReleaseTag: true,
+					Modules: map[string]ModuleManifest{
+						"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.0.0", To: "v1.0.1"},
+					},
+					Tags: []string{"config/v1.0.1"},
+				},
+				{
+					ID: "2021-10-27.2",
+					Modules: map[string]ModuleManifest{
+						".": {ModulePath: "github.com/aws/aws-sdk-go-v2", To: "v1.0.0-preview"},
+					},
+					Tags: []string{"v1.0.0-preview"},
+				},
+			},
+			Expect: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
+				ID:             "2021-10-28",
+				WithReleaseTag: true,
+				Modules: map[string]ModuleManifest{
+					".":      {ModulePath: "github.com/aws/aws-sdk-go-v2", To: "v1.0.0-preview"},
+					"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.0.0", To: "v1.0.1"},
+				},
+				Tags: []string{"config/v1.0.1", "v1.0.0-preview"},
+			},
+		},
+		"version line": {
+			Manifests: []Manifest{
+				{
+					ID: "2021-10-27",
+					Modules: map[string]ModuleManifest{
+						"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.24.0", To: "v1.24.1"},
+					},
+					Tags:        []string{"config/v1.24.1"},
+					VersionLine: "v1.24",
+				},
+				{
+					ID: "2021-10-27.2",
+					Modules: map[string]ModuleManifest{
+						".": {ModulePath: "github.com/aws/aws-sdk-go-v2", From: "v1.24.3", To: "v1.24.4"},
+					},
+					Tags:        []string{"v1.24.4"},
+					VersionLine: "v1.24",
+				},
+			},
+			Expect: Manifest{
+				SchemaVersion: ManifestSchemaVersion,
+				ID:            "2021-10-28",
+				Modules: map[string]ModuleManifest{
+					".":      {ModulePath: "github.com/aws/aws-sdk-go-v2", From: "v1.24.3", To: "v1.24.4"},
+					"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.24.0", To: "v1.24.1"},
+				},
+				Tags:        []string{"config/v1.24.1", "v1.24.4"},
+				VersionLine: "v1.24",
+			},
+		},
+		"different version lines": {
+			Manifests: []Manifest{
+				{
+					ID: "2021-10-27",
+					Modules: map[string]ModuleManifest{
+						"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.24.0", To: "v1.24.1"},
+					},
+					Tags:        []string{"config/v1.24.1"},
+					VersionLine: "v1.24",
+				},
+				{
+					ID: "2021-10-27.2",
+					Modules: map[string]ModuleManifest{
+						".": {ModulePath: "github.com/aws/aws-sdk-go-v2", From: "v1.26.0", To: "v1.26.1"},
+					},
+					Tags: []string{"v1.26.1"},
+				},
+			},
+			ExpectErr: `version line "" of manifest 2, 2021-10-27.2, does not match version line "v1.24"`,
+		},
+		"overlapping": {
+			Manifests: []Manifest{
+				{
+					ID: "2021-10-27",
+					Modules: map[string]ModuleManifest{
+						"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.0.0", To: "v1.0.1"},
+					},
+					Tags: []string{"config/v1.0.1"},
+				},
+				{
+					ID: "2021-10-27.2",
+					Modules: map[string]ModuleManifest{
+						"config": {ModulePath: "github.com/aws/aws-sdk-go-v2/config", From: "v1.0.0", To: "v1.1.0"},
+					},
+					Tags: []string{"config/v1.1.0"},
+				},
+			},
+			ExpectErr: "module config is present in more than one manifest",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			merged, err := MergeManifests("2021-10-28", tt.Manifests...)
+			if len(tt.ExpectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.Expect, merged); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
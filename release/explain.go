@@ -0,0 +1,82 @@
+package release
+
+import (
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+)
+
+// Explanation describes why a module is being released.
+type Explanation struct {
+	// The module's Go module path
+	ModulePath string
+
+	// The module's relative path from the repository root
+	RelativeRepoPath string
+
+	// The most recent semver tagged release
+	Latest string
+
+	// The changes for the module
+	Changes ModuleChange
+
+	// The files changed within the module since its latest release
+	FileChanges []string
+
+	// The relative repository paths of new submodules carved out of the module since its latest release
+	CarvedOutModules []string
+
+	// The relative repository paths of the modules that caused the DependencyUpdate change, starting with the module
+	// whose source changes required a release, and ending with the module's direct dependency
+	DependencyChain []string
+
+	// The version increment determined by the module's annotations
+	Increment changelog.SemVerIncrement
+
+	// The annotations whose types decided the version increment
+	DecidingAnnotations []changelog.Annotation
+
+	// The remaining annotations of the module
+	OtherAnnotations []changelog.Annotation
+}
+
+// ExplainModule returns the explanation of why the module at the relative repository path is being released. Returns
+// false if the module is not found.
+func ExplainModule(modules map[string]*Module, relPath string) (Explanation, bool) {
+	var modulePath string
+	var module *Module
+	for mp, m := range modules {
+		if m.RelativeRepoPath == relPath {
+			modulePath, module = mp, m
+			break
+		}
+	}
+	if module == nil {
+		return Explanation{}, false
+	}
+
+	explanation := Explanation{
+		ModulePath:       modulePath,
+		RelativeRepoPath: module.RelativeRepoPath,
+		Latest:           module.Latest,
+		Changes:          module.Changes,
+		FileChanges:      module.FileChanges,
+		CarvedOutModules: module.CarvedOutModules,
+		Increment:        changelog.GetVersionIncrement(module.ChangeAnnotations),
+	}
+
+	for _, dependency := range DependencyUpdateChain(modules, modulePath) {
+		if m, ok := modules[dependency]; ok {
+			dependency = m.RelativeRepoPath
+		}
+		explanation.DependencyChain = append(explanation.DependencyChain, dependency)
+	}
+
+	for _, annotation := range module.ChangeAnnotations {
+		if explanation.Increment != changelog.DefaultBump && annotation.Type.VersionIncrement() == explanation.Increment {
+			explanation.DecidingAnnotations = append(explanation.DecidingAnnotations, annotation)
+		} else {
+			explanation.OtherAnnotations = append(explanation.OtherAnnotations, annotation)
+		}
+	}
+
+	return explanation, true
+}
@@ -0,0 +1,72 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/mod/modfile"
+)
+
+func TestExplainModule(t *testing.T) {
+	newFile := func(path string, requires ...string) *modfile.File {
+		f := &modfile.File{}
+		if err := f.AddModuleStmt(path); err != nil {
+			t.Fatal(err)
+		}
+		for _, require := range requires {
+			if err := f.AddRequire(require, "v1.0.0"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return f
+	}
+
+	feature := changelog.Annotation{ID: "1", Type: changelog.FeatureChangeType, Description: "a"}
+	bugfix := changelog.Annotation{ID: "2", Type: changelog.BugFixChangeType, Description: "b"}
+
+	modules := map[string]*Module{
+		"example.com/core": {
+			File:             newFile("example.com/core"),
+			RelativeRepoPath: "core",
+			Latest:           "v1.0.0",
+			Changes:          SourceChange,
+			FileChanges:      []string{"core/core.go"},
+		},
+		"example.com/service": {
+			File:              newFile("example.com/service", "example.com/core"),
+			RelativeRepoPath:  "service",
+			Latest:            "v1.2.0",
+			Changes:           SourceChange,
+			CarvedOutModules:  []string{"service/sub"},
+			ChangeAnnotations: []changelog.Annotation{bugfix, feature},
+		},
+	}
+	if err := CalculateDependencyUpdates(modules); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	explanation, ok := ExplainModule(modules, "service")
+	if !ok {
+		t.Fatalf("expect module to be found")
+	}
+
+	expect := Explanation{
+		ModulePath:          "example.com/service",
+		RelativeRepoPath:    "service",
+		Latest:              "v1.2.0",
+		Changes:             SourceChange | DependencyUpdate,
+		CarvedOutModules:    []string{"service/sub"},
+		DependencyChain:     []string{"core"},
+		Increment:           changelog.MinorBump,
+		DecidingAnnotations: []changelog.Annotation{feature},
+		OtherAnnotations:    []changelog.Annotation{bugfix},
+	}
+	if diff := cmp.Diff(expect, explanation); len(diff) > 0 {
+		t.Error(diff)
+	}
+
+	if _, ok := ExplainModule(modules, "unknown"); ok {
+		t.Errorf("expect unknown module to not be found")
+	}
+}
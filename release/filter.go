@@ -0,0 +1,76 @@
+package release
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/gomod"
+)
+
+// matchModulePatterns returns whether the relative repository path of a module matches any of the path patterns.
+func matchModulePatterns(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, relPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateModulePatterns returns an error if any of the module path patterns are malformed.
+func validateModulePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid module pattern %q, %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// selectModules returns the module paths selected for release by the include and exclude patterns. Modules are
+// selected if they match an include pattern, or all modules if there are no include patterns, and do not match an
+// exclude pattern. The changed in-repository dependencies, direct or transitive, of selected modules are always
+// selected as the selected modules may depend on their unreleased changes. The module changes must include dependency
+// updates, so that every module on a require path from a selected module to a changed dependency is also selected.
+func selectModules(modules map[string]*Module, graph *gomod.ModuleGraph, include, exclude []string) (map[string]struct{}, error) {
+	var roots []string
+	for modulePath, module := range modules {
+		if len(include) > 0 && !matchModulePatterns(include, module.RelativeRepoPath) {
+			continue
+		}
+		if matchModulePatterns(exclude, module.RelativeRepoPath) {
+			continue
+		}
+		roots = append(roots, modulePath)
+	}
+	sort.Strings(roots)
+
+	closure, err := graph.Subgraph(roots, gomod.DependenciesDirection, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]struct{}, len(roots))
+	for _, modulePath := range roots {
+		selected[modulePath] = struct{}{}
+	}
+	for _, modulePath := range closure.Modules() {
+		if modules[modulePath].Changes != 0 {
+			selected[modulePath] = struct{}{}
+		}
+	}
+
+	return selected, nil
+}
+
+// deferUnselectedModules moves the changes of modules that were not selected for release to their deferred changes.
+func deferUnselectedModules(modules map[string]*Module, selected map[string]struct{}) {
+	for modulePath, module := range modules {
+		if _, ok := selected[modulePath]; ok || module.Changes == 0 {
+			continue
+		}
+		module.DeferredChanges |= module.Changes
+		module.Changes = 0
+	}
+}
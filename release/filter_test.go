@@ -0,0 +1,142 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/mod/modfile"
+)
+
+func TestSelectModules(t *testing.T) {
+	newModule := func(path string, changes ModuleChange, requires ...string) *Module {
+		f := &modfile.File{}
+		if err := f.AddModuleStmt(path); err != nil {
+			t.Fatal(err)
+		}
+		for _, require := range requires {
+			if err := f.AddRequire(require, "v1.0.0"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return &Module{File: f, RelativeRepoPath: strings.TrimPrefix(path, "example.com/"), Latest: "v1.0.0", Changes: changes}
+	}
+
+	defaultModules := func() map[string]*Module {
+		return map[string]*Module{
+			"example.com/core":             newModule("example.com/core", SourceChange),
+			"example.com/service/s3":       newModule("example.com/service/s3", SourceChange, "example.com/core"),
+			"example.com/service/s3/sub":   newModule("example.com/service/s3/sub", 0, "example.com/service/s3"),
+			"example.com/service/dynamodb": newModule("example.com/service/dynamodb", SourceChange),
+		}
+	}
+
+	cases := map[string]struct {
+		Modules  map[string]*Module
+		Include  []string
+		Exclude  []string
+		Released map[string]ModuleChange
+		Deferred map[string]ModuleChange
+	}{
+		"no filters": {
+			Released: map[string]ModuleChange{
+				"example.com/core":             SourceChange,
+				"example.com/service/s3":       SourceChange | DependencyUpdate,
+				"example.com/service/s3/sub":   DependencyUpdate,
+				"example.com/service/dynamodb": SourceChange,
+			},
+			Deferred: map[string]ModuleChange{},
+		},
+		"include with dependency closure": {
+			Include: []string{"service/s3", "service/s3/*"},
+			Released: map[string]ModuleChange{
+				"example.com/core":           SourceChange,
+				"example.com/service/s3":     SourceChange | DependencyUpdate,
+				"example.com/service/s3/sub": DependencyUpdate,
+			},
+			Deferred: map[string]ModuleChange{
+				"example.com/service/dynamodb": SourceChange,
+			},
+		},
+		"exclude dependents": {
+			Exclude: []string{"service/*", "service/*/*"},
+			Released: map[string]ModuleChange{
+				"example.com/core": SourceChange,
+			},
+			Deferred: map[string]ModuleChange{
+				"example.com/service/s3":       SourceChange | DependencyUpdate,
+				"example.com/service/s3/sub":   DependencyUpdate,
+				"example.com/service/dynamodb": SourceChange,
+			},
+		},
+		"exclude dependency of included module": {
+			Include: []string{"service/s3"},
+			Exclude: []string{"core"},
+			Released: map[string]ModuleChange{
+				"example.com/core":       SourceChange,
+				"example.com/service/s3": SourceChange | DependencyUpdate,
+			},
+			Deferred: map[string]ModuleChange{
+				"example.com/service/s3/sub":   DependencyUpdate,
+				"example.com/service/dynamodb": SourceChange,
+			},
+		},
+		"include with unchanged intermediate dependency": {
+			Modules: map[string]*Module{
+				"example.com/a": newModule("example.com/a", 0, "example.com/b"),
+				"example.com/b": newModule("example.com/b", 0, "example.com/c"),
+				"example.com/c": newModule("example.com/c", SourceChange),
+				"example.com/d": newModule("example.com/d", SourceChange),
+			},
+			Include: []string{"a"},
+			Released: map[string]ModuleChange{
+				"example.com/a": DependencyUpdate,
+				"example.com/b": DependencyUpdate,
+				"example.com/c": SourceChange,
+			},
+			Deferred: map[string]ModuleChange{
+				"example.com/d": SourceChange,
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			modules := tt.Modules
+			if modules == nil {
+				modules = defaultModules()
+			}
+
+			graph, err := newModuleGraph(modules)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if err := calculateDependencyUpdates(modules, graph); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			selected, err := selectModules(modules, graph, tt.Include, tt.Exclude)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			deferUnselectedModules(modules, selected)
+
+			released := make(map[string]ModuleChange)
+			deferred := make(map[string]ModuleChange)
+			for modulePath, module := range modules {
+				if module.Changes != 0 {
+					released[modulePath] = module.Changes
+				}
+				if module.DeferredChanges != 0 {
+					deferred[modulePath] = module.DeferredChanges
+				}
+			}
+
+			if diff := cmp.Diff(tt.Released, released); len(diff) > 0 {
+				t.Errorf("released: %s", diff)
+			}
+			if diff := cmp.Diff(tt.Deferred, deferred); len(diff) > 0 {
+				t.Errorf("deferred: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package release
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+)
+
+// AnnotationIssue is a problem found with the changelog annotations for the modules to be released.
+type AnnotationIssue struct {
+	// The annotation identifier the issue applies to, empty if the issue applies to a module.
+	AnnotationID string
+
+	// The relative repository path of the module the issue applies to, if any.
+	Module string
+
+	// A description of the issue.
+	Message string
+}
+
+// String returns the issue description.
+func (i AnnotationIssue) String() string {
+	switch {
+	case len(i.AnnotationID) > 0 && len(i.Module) > 0:
+		return fmt.Sprintf("annotation %v: module %v: %v", i.AnnotationID, i.Module, i.Message)
+	case len(i.AnnotationID) > 0:
+		return fmt.Sprintf("annotation %v: %v", i.AnnotationID, i.Message)
+	default:
+		return fmt.Sprintf("module %v: %v", i.Module, i.Message)
+	}
+}
+
+// LintAnnotations checks the changelog annotations against the modules returned by Calculate. Issues are reported
+// for modules with source changes that are not covered by an annotation, annotations that refer to modules that do
+// not exist, and annotations with duplicate descriptions. Modules configured to not be tagged are not required to
+// have an annotation. Issues are sorted by module, then annotation identifier.
+func LintAnnotations(modules map[string]*Module, annotations []changelog.Annotation) (issues []AnnotationIssue) {
+	relPaths := make(map[string]*Module, len(modules))
+	for _, module := range modules {
+		relPaths[module.RelativeRepoPath] = module
+	}
+
+	for _, module := range modules {
+		if module.ModuleConfig.NoTag || module.Changes&SourceChange == 0 || len(module.ChangeAnnotations) > 0 {
+			continue
+		}
+		issues = append(issues, AnnotationIssue{
+			Module:  module.RelativeRepoPath,
+			Message: "has source changes since its last tagged release, but no changelog annotation",
+		})
+	}
+
+	descriptions := make(map[string]string)
+	sorted := append([]changelog.Annotation{}, annotations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	for _, annotation := range sorted {
+		for _, relPath := range annotation.Modules {
+			if _, ok := relPaths[relPath]; ok {
+				continue
+			}
+			issues = append(issues, AnnotationIssue{
+				AnnotationID: annotation.ID,
+				Module:       relPath,
+				Message:      "module does not exist in the repository",
+			})
+		}
+
+		description := strings.TrimSpace(annotation.Description)
+		if other, ok := descriptions[description]; ok {
+			issues = append(issues, AnnotationIssue{
+				AnnotationID: annotation.ID,
+				Message:      fmt.Sprintf("duplicate description of annotation %v", other),
+			})
+			continue
+		}
+		descriptions[description] = annotation.ID
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Module != issues[j].Module {
+			return issues[i].Module < issues[j].Module
+		}
+		return issues[i].AnnotationID < issues[j].AnnotationID
+	})
+
+	return issues
+}
@@ -0,0 +1,84 @@
+package release
+
+import (
+	"testing"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintAnnotations(t *testing.T) {
+	configAnnotation := changelog.Annotation{
+		ID:          "a",
+		Type:        changelog.FeatureChangeType,
+		Description: "Add config feature",
+		Modules:     []string{"config"},
+	}
+
+	modules := map[string]*Module{
+		"github.com/aws/aws-sdk-go-v2/config": {
+			RelativeRepoPath:  "config",
+			Latest:            "v1.0.0",
+			Changes:           SourceChange,
+			ChangeAnnotations: []changelog.Annotation{configAnnotation},
+		},
+		"github.com/aws/aws-sdk-go-v2/service/s3": {
+			RelativeRepoPath: "service/s3",
+			Latest:           "v1.0.0",
+			Changes:          SourceChange,
+		},
+		"github.com/aws/aws-sdk-go-v2/internal/protocoltest": {
+			RelativeRepoPath: "internal/protocoltest",
+			Latest:           "v1.0.0",
+			Changes:          SourceChange,
+			ModuleConfig:     repotools.ModuleConfig{NoTag: true},
+		},
+		"github.com/aws/aws-sdk-go-v2/credentials": {
+			RelativeRepoPath: "credentials",
+			Latest:           "v1.0.0",
+			Changes:          DependencyUpdate,
+		},
+		"github.com/aws/aws-sdk-go-v2/feature/s3": {
+			RelativeRepoPath: "feature/s3",
+			Changes:          NewModule,
+		},
+	}
+
+	annotations := []changelog.Annotation{
+		{
+			ID:          "c",
+			Type:        changelog.BugFixChangeType,
+			Description: " Add config feature\n",
+			Modules:     []string{"credentials"},
+		},
+		configAnnotation,
+		{
+			ID:          "b",
+			Type:        changelog.BugFixChangeType,
+			Description: "Fix removed module",
+			Modules:     []string{"service/s4", "config"},
+		},
+	}
+
+	expect := []AnnotationIssue{
+		{
+			AnnotationID: "c",
+			Message:      "duplicate description of annotation a",
+		},
+		{
+			Module:  "service/s3",
+			Message: "has source changes since its last tagged release, but no changelog annotation",
+		},
+		{
+			AnnotationID: "b",
+			Module:       "service/s4",
+			Message:      "module does not exist in the repository",
+		},
+	}
+
+	issues := LintAnnotations(modules, annotations)
+	if diff := cmp.Diff(expect, issues); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,136 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/awslabs/aws-go-multi-module-repository-tools/git"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/internal/semver"
+)
+
+// ManifestSchemaVersion is the version of the release manifest schema written by this package. Manifests written
+// before the schema was versioned omit the version, and are read as version 1.
+const ManifestSchemaVersion = 1
+
+// LoadManifest reads and validates the release manifest file located at path.
+func LoadManifest(path string) (Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest, err := DecodeManifest(bytes.NewReader(b))
+	if err != nil {
+		return Manifest{}, fmt.Errorf("invalid release manifest %v, %w", path, err)
+	}
+
+	return manifest, nil
+}
+
+// DecodeManifest decodes and validates a release manifest from the reader.
+func DecodeManifest(r io.Reader) (manifest Manifest, err error) {
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return Manifest{}, err
+	}
+
+	switch manifest.SchemaVersion {
+	case 0:
+		manifest.SchemaVersion = ManifestSchemaVersion
+	case ManifestSchemaVersion:
+	default:
+		return Manifest{}, fmt.Errorf("unsupported manifest schema version %d, expect %d",
+			manifest.SchemaVersion, ManifestSchemaVersion)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return Manifest{}, err
+	}
+
+	return manifest, nil
+}
+
+// WriteManifest validates the release manifest, and writes it to the writer as indented JSON.
+func WriteManifest(w io.Writer, manifest Manifest) error {
+	if manifest.SchemaVersion == 0 {
+		manifest.SchemaVersion = ManifestSchemaVersion
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return fmt.Errorf("invalid release manifest, %w", err)
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	_, err = w.Write(b)
+	return err
+}
+
+// Validate checks that the manifest is internally consistent. Each module's version must be valid and greater than
+// the version it is released from, and the manifest's tags must be exactly the tags of the modules to be released.
+func (m Manifest) Validate() error {
+	if m.SchemaVersion != ManifestSchemaVersion {
+		return fmt.Errorf("unsupported manifest schema version %d, expect %d", m.SchemaVersion, ManifestSchemaVersion)
+	}
+	if len(m.VersionLine) > 0 {
+		if line, err := ParseVersionLine(m.VersionLine); err != nil || line != m.VersionLine {
+			return fmt.Errorf("invalid manifest version line %q, expect major and minor version, (e.g. v1.24)", m.VersionLine)
+		}
+	}
+
+	tags := make(map[string]struct{}, len(m.Tags))
+	for _, tag := range m.Tags {
+		if _, ok := tags[tag]; ok {
+			return fmt.Errorf("duplicate tag %v", tag)
+		}
+		tags[tag] = struct{}{}
+	}
+
+	moduleTags := make(map[string]string, len(m.Modules))
+	for _, relPath := range sortedModulePaths(m) {
+		mm := m.Modules[relPath]
+
+		if len(mm.ModulePath) == 0 {
+			return fmt.Errorf("module %v has no module path", relPath)
+		}
+		if !semver.IsValid(mm.To) {
+			return fmt.Errorf("module %v has invalid version %q", relPath, mm.To)
+		}
+		if len(mm.From) > 0 {
+			if !semver.IsValid(mm.From) {
+				return fmt.Errorf("module %v has invalid previous version %q", relPath, mm.From)
+			}
+			if semver.Compare(mm.From, mm.To) >= 0 {
+				return fmt.Errorf("module %v version %v must be greater than previous version %v",
+					relPath, mm.To, mm.From)
+			}
+		}
+
+		tag, err := git.ToModuleTag(git.ModuleTagPath(relPath, mm.ReleaseModulePath()), mm.To)
+		if err != nil {
+			return fmt.Errorf("module %v has invalid tag, %w", relPath, err)
+		}
+		if other, ok := moduleTags[tag]; ok {
+			return fmt.Errorf("modules %v and %v have the same tag %v", other, relPath, tag)
+		}
+		moduleTags[tag] = relPath
+
+		if _, ok := tags[tag]; !ok {
+			return fmt.Errorf("module %v tag %v not found in manifest tags", relPath, tag)
+		}
+	}
+
+	for _, tag := range m.Tags {
+		if _, ok := moduleTags[tag]; !ok {
+			return fmt.Errorf("tag %v does not match any module version", tag)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package release
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeManifest(t *testing.T) {
+	cases := map[string]struct {
+		Manifest  string
+		Expect    Manifest
+		ExpectErr string
+	}{
+		"valid": {
+			Manifest: `{
+    "schema_version": 1,
+    "id": "2021-10-27",
+    "with_release_tag": true,
+    "modules": {
+        ".": {"module_path": "github.com/aws/aws-sdk-go-v2", "from": "v1.2.3", "to": "v1.3.0"},
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "to": "v1.0.0-preview"}
+    },
+    "tags": ["config/v1.0.0-preview", "v1.3.0"]
+}`,
+			Expect: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
+				ID:             "2021-10-27",
+				WithReleaseTag: true,
+				Modules: map[string]ModuleManifest{
+					".": {
+						ModulePath: "github.com/aws/aws-sdk-go-v2",
+						From:       "v1.2.3",
+						To:         "v1.3.0",
+					},
+					"config": {
+						ModulePath: "github.com/aws/aws-sdk-go-v2/config",
+						To:         "v1.0.0-preview",
+					},
+				},
+				Tags: []string{"config/v1.0.0-preview", "v1.3.0"},
+			},
+		},
+		"legacy without schema version": {
+			Manifest: `{
+    "id": "v1.2.4",
+    "modules": {
+        ".": {"module_path": "github.com/aws/smithy-go", "from": "v1.2.3", "to": "v1.2.4"}
+    },
+    "tags": ["v1.2.4"]
+}`,
+			Expect: Manifest{
+				SchemaVersion: ManifestSchemaVersion,
+				ID:            "v1.2.4",
+				Modules: map[string]ModuleManifest{
+					".": {
+						ModulePath: "github.com/aws/smithy-go",
+						From:       "v1.2.3",
+						To:         "v1.2.4",
+					},
+				},
+				Tags: []string{"v1.2.4"},
+			},
+		},
+		"major version upgrade": {
+			Manifest: `{
+    "schema_version": 1,
+    "id": "2021-10-27",
+    "modules": {
+        "config": {
+            "module_path": "github.com/aws/aws-sdk-go-v2/config",
+            "from": "v1.3.0",
+            "to": "v2.0.0",
+            "major_version_upgrade": {
+                "from_module_path": "github.com/aws/aws-sdk-go-v2/config",
+                "to_module_path": "github.com/aws/aws-sdk-go-v2/config/v2"
+            }
+        }
+    },
+    "tags": ["config/v2.0.0"]
+}`,
+			Expect: Manifest{
+				SchemaVersion: ManifestSchemaVersion,
+				ID:            "2021-10-27",
+				Modules: map[string]ModuleManifest{
+					"config": {
+						ModulePath: "github.com/aws/aws-sdk-go-v2/config",
+						From:       "v1.3.0",
+						To:         "v2.0.0",
+						MajorVersionUpgrade: &MajorVersionUpgrade{
+							FromModulePath: "github.com/aws/aws-sdk-go-v2/config",
+							ToModulePath:   "github.com/aws/aws-sdk-go-v2/config/v2",
+						},
+					},
+				},
+				Tags: []string{"config/v2.0.0"},
+			},
+		},
+		"version line": {
+			Manifest: `{
+    "schema_version": 1,
+    "id": "2021-10-27",
+    "modules": {
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "from": "v1.24.3", "to": "v1.24.4"}
+    },
+    "tags": ["config/v1.24.4"],
+    "version_line": "v1.24"
+}`,
+			Expect: Manifest{
+				SchemaVersion: ManifestSchemaVersion,
+				ID:            "2021-10-27",
+				Modules: map[string]ModuleManifest{
+					"config": {
+						ModulePath: "github.com/aws/aws-sdk-go-v2/config",
+						From:       "v1.24.3",
+						To:         "v1.24.4",
+					},
+				},
+				Tags:        []string{"config/v1.24.4"},
+				VersionLine: "v1.24",
+			},
+		},
+		"invalid version line": {
+			Manifest:  `{"schema_version": 1, "id": "2021-10-27", "version_line": "v1.24.x"}`,
+			ExpectErr: `invalid manifest version line "v1.24.x"`,
+		},
+		"unsupported schema version": {
+			Manifest:  `{"schema_version": 2, "id": "2021-10-27"}`,
+			ExpectErr: "unsupported manifest schema version 2",
+		},
+		"tag does not match version": {
+			Manifest: `{
+    "schema_version": 1,
+    "modules": {
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "from": "v1.0.0", "to": "v1.0.1"}
+    },
+    "tags": ["config/v1.0.2"]
+}`,
+			ExpectErr: "module config tag config/v1.0.1 not found in manifest tags",
+		},
+		"tag without module": {
+			Manifest: `{
+    "schema_version": 1,
+    "modules": {
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "from": "v1.0.0", "to": "v1.0.1"}
+    },
+    "tags": ["config/v1.0.1", "service/s3/v1.0.1"]
+}`,
+			ExpectErr: "tag service/s3/v1.0.1 does not match any module version",
+		},
+		"duplicate tag": {
+			Manifest: `{
+    "schema_version": 1,
+    "modules": {
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "from": "v1.0.0", "to": "v1.0.1"}
+    },
+    "tags": ["config/v1.0.1", "config/v1.0.1"]
+}`,
+			ExpectErr: "duplicate tag config/v1.0.1",
+		},
+		"version not increased": {
+			Manifest: `{
+    "schema_version": 1,
+    "modules": {
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "from": "v1.0.1", "to": "v1.0.1"}
+    },
+    "tags": ["config/v1.0.1"]
+}`,
+			ExpectErr: "module config version v1.0.1 must be greater than previous version v1.0.1",
+		},
+		"invalid version": {
+			Manifest: `{
+    "schema_version": 1,
+    "modules": {
+        "config": {"module_path": "github.com/aws/aws-sdk-go-v2/config", "to": "1.0.1"}
+    },
+    "tags": ["config/1.0.1"]
+}`,
+			ExpectErr: `module config has invalid version "1.0.1"`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			manifest, err := DecodeManifest(strings.NewReader(tt.Manifest))
+			if len(tt.ExpectErr) > 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if diff := cmp.Diff(tt.Expect, manifest); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	manifest := Manifest{
+		ID: "v1.2.4",
+		Modules: map[string]ModuleManifest{
+			".": {
+				ModulePath: "github.com/aws/smithy-go",
+				From:       "v1.2.3",
+				To:         "v1.2.4",
+			},
+		},
+		Tags: []string{"v1.2.4"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteManifest(&buf, manifest); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	actual, err := DecodeManifest(&buf)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	manifest.SchemaVersion = ManifestSchemaVersion
+	if diff := cmp.Diff(manifest, actual); len(diff) > 0 {
+		t.Error(diff)
+	}
+
+	manifest.Tags = []string{"v1.2.5"}
+	if err := WriteManifest(&buf, manifest); err == nil {
+		t.Errorf("expect error for invalid manifest, got none")
+	}
+}
@@ -19,10 +19,15 @@ import (
 
 // Manifest is a release description of changed modules and their associated tags to be released.
 type Manifest struct {
+	SchemaVersion  int                       `json:"schema_version"`
 	ID             string                    `json:"id"`
 	WithReleaseTag bool                      `json:"with_release_tag"`
 	Modules        map[string]ModuleManifest `json:"modules"`
 	Tags           []string                  `json:"tags"`
+
+	// The major and minor version line, (e.g. v1.24), the release was calculated within, empty if released from the
+	// latest tags.
+	VersionLine string `json:"version_line,omitempty"`
 }
 
 // ModuleManifest describes a changed module for release.
@@ -36,6 +41,54 @@ type ModuleManifest struct {
 	FileChanges []string     `json:"file_changes,omitempty"`
 
 	Annotations Annotations `json:"annotations,omitempty"`
+
+	MajorVersionUpgrade *MajorVersionUpgrade `json:"major_version_upgrade,omitempty"`
+}
+
+// ReleaseModulePath returns the Go module path the module will be released with. This is the module path after any
+// required major version upgrade has been applied.
+func (m ModuleManifest) ReleaseModulePath() string {
+	if m.MajorVersionUpgrade != nil {
+		return m.MajorVersionUpgrade.ToModulePath
+	}
+	return m.ModulePath
+}
+
+// MajorVersionUpgrade describes the module path migration required to release a module at a new major version.
+type MajorVersionUpgrade struct {
+	// The module path currently recorded in the module's go.mod
+	FromModulePath string `json:"from_module_path"`
+
+	// The module path the module's go.mod module directive must be rewritten to
+	ToModulePath string `json:"to_module_path"`
+
+	// The relative repository paths of modules that require FromModulePath, and must update their go.mod require
+	// directive and import paths to ToModulePath. The upgraded module's own import paths must be updated as well.
+	Dependents []string `json:"dependents,omitempty"`
+}
+
+// MajorVersionModulePath returns the module path updated to reflect the major version of the provided semver version.
+// For example:
+//
+//	Path: example.com/foo     Version: v1.2.3 => example.com/foo
+//	Path: example.com/foo     Version: v2.0.0 => example.com/foo/v2
+//	Path: example.com/foo/v2  Version: v3.0.0 => example.com/foo/v3
+func MajorVersionModulePath(modulePath, version string) (string, error) {
+	prefix, _, ok := module.SplitPathVersion(modulePath)
+	if !ok {
+		return "", fmt.Errorf("invalid module path, %v", modulePath)
+	}
+
+	major := semver.Major(version)
+	if len(major) == 0 {
+		return "", fmt.Errorf("invalid semantic version: %v", version)
+	}
+
+	if major == "v0" || major == "v1" {
+		return prefix, nil
+	}
+
+	return prefix + "/" + major, nil
 }
 
 func getNewModuleVersion(pathMajor string, increment changelog.SemVerIncrement, config repotools.ModuleConfig, preReleaseIdentifier string) (nextVersion string) {
@@ -80,6 +133,10 @@ func CalculateNextVersion(modulePath string, latest string, config repotools.Mod
 
 	isPreRelease := len(preReleaseIdentifier) > 0
 
+	if len(config.VersionLine) > 0 {
+		return calculateVersionLineVersion(modulePath, latest, increment, config, preReleaseIdentifier)
+	}
+
 	if len(latest) == 0 {
 		next = getNewModuleVersion(pathMajor, increment, config, preReleaseIdentifier)
 		return next, nil
@@ -90,7 +147,12 @@ func CalculateNextVersion(modulePath string, latest string, config repotools.Mod
 		return "", fmt.Errorf("failed to parse semver: %v, %v", latest, parsed.Err)
 	}
 
-	if isPreRelease {
+	if increment == changelog.MajorBump && !isMajorPreRelease(parsed) {
+		next, err = calculateMajorVersion(parsed, config, preReleaseIdentifier)
+		if err != nil {
+			return "", err
+		}
+	} else if isPreRelease {
 		next, err = calculatePreReleaseVersion(parsed, increment, config, preReleaseIdentifier)
 		if err != nil {
 			return "", err
@@ -109,6 +171,40 @@ func CalculateNextVersion(modulePath string, latest string, config repotools.Mod
 	return next, nil
 }
 
+// calculateMajorVersion increments the major version component, resetting the minor and patch components. The
+// pre-release identifier, or the module's configured pre-release, is applied to the new major version if set.
+// Examples:
+//
+//	v0.4.2 => v1.0.0
+//	v1.4.2 => v2.0.0
+//	v2.4.2 => v3.0.0-rc (preReleaseIdentifier = "rc")
+func calculateMajorVersion(parsed semver.Parsed, config repotools.ModuleConfig, preReleaseIdentifier string) (string, error) {
+	if err := incrementStrInt(&parsed.Major); err != nil {
+		return "", err
+	}
+	parsed.Minor = "0"
+	parsed.Patch = "0"
+	parsed.Prerelease = ""
+
+	identifier := config.PreRelease
+	if len(preReleaseIdentifier) > 0 {
+		identifier = preReleaseIdentifier
+	}
+
+	if len(identifier) > 0 {
+		parsed.Prerelease = formatPreRelease(identifier)
+	}
+
+	return parsed.String(), nil
+}
+
+// isMajorPreRelease returns whether the version is a pre-release of a new major version, (e.g. v2.0.0-preview). A
+// breaking change of a major pre-release is part of the major version already being released, so the major version
+// is not incremented again.
+func isMajorPreRelease(parsed semver.Parsed) bool {
+	return len(parsed.Prerelease) > 0 && parsed.Minor == "0" && parsed.Patch == "0"
+}
+
 func calculatePreReleaseVersion(parsed semver.Parsed, increment changelog.SemVerIncrement, config repotools.ModuleConfig, preReleaseIdentifier string) (string, error) {
 	if increment == changelog.ReleaseBump || len(parsed.Prerelease) > 0 {
 		// For release bumps we append the pre-release identifier to the existing
@@ -259,11 +355,17 @@ func incrementPrerelease(prerelease *string, identifier string) error {
 // BuildReleaseManifest given a mapping of Go module paths to their Module
 // descriptions, returns a summarized manifest for release.
 func BuildReleaseManifest(moduleTree *gomod.ModuleTree, id string, modules map[string]*Module, verbose bool, preRelease string) (rm Manifest, err error) {
+	rm.SchemaVersion = ManifestSchemaVersion
 	rm.ID = id
 	rm.WithReleaseTag = true
 
 	rm.Modules = make(map[string]ModuleManifest)
 
+	graph, err := newModuleGraph(modules)
+	if err != nil {
+		return Manifest{}, err
+	}
+
 	for modulePath, mod := range modules {
 		if mod.Changes == 0 || mod.ModuleConfig.NoTag {
 			continue
@@ -288,9 +390,16 @@ func BuildReleaseManifest(moduleTree *gomod.ModuleTree, id string, modules map[s
 			Annotations: annotationsToIDs(mod.ChangeAnnotations),
 		}
 
+		if len(mod.Latest) > 0 {
+			mm.MajorVersionUpgrade, err = getMajorVersionUpgrade(modulePath, nextVersion, modules, graph)
+			if err != nil {
+				return Manifest{}, err
+			}
+		}
+
 		rm.Modules[mod.RelativeRepoPath] = mm
 
-		moduleTag, err := git.ToModuleTag(mod.RelativeRepoPath, nextVersion)
+		moduleTag, err := git.ToModuleTag(git.ModuleTagPath(mod.RelativeRepoPath, mm.ReleaseModulePath()), nextVersion)
 		if err != nil {
 			return Manifest{}, err
 		}
@@ -325,6 +434,31 @@ func BuildReleaseManifest(moduleTree *gomod.ModuleTree, id string, modules map[s
 	return rm, nil
 }
 
+// getMajorVersionUpgrade returns the module path migration required for the module to be released at the next
+// version. Returns nil if the next version does not require the module path to be changed.
+func getMajorVersionUpgrade(modulePath, nextVersion string, modules map[string]*Module, graph *gomod.ModuleGraph) (*MajorVersionUpgrade, error) {
+	nextModulePath, err := MajorVersionModulePath(modulePath, nextVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if nextModulePath == modulePath {
+		return nil, nil
+	}
+
+	var dependents []string
+	for _, dependent := range graph.Dependents(modulePath) {
+		dependents = append(dependents, modules[dependent].RelativeRepoPath)
+	}
+	sort.Strings(dependents)
+
+	return &MajorVersionUpgrade{
+		FromModulePath: modulePath,
+		ToModulePath:   nextModulePath,
+		Dependents:     dependents,
+	}, nil
+}
+
 // FindModuleViaRelativeRepoPath Searches through the map of calculated module
 // changes, for a module with the relative repository path specified. If a
 // module is found it will be returned.
@@ -369,8 +503,17 @@ type Module struct {
 	// The changes for the module
 	Changes ModuleChange
 
+	// The module path of the dependency whose release caused the DependencyUpdate change for this module
+	DependencyUpdateFrom string
+
+	// The changes for the module that were deferred to a later release by the CalculateOptions module filters
+	DeferredChanges ModuleChange
+
 	FileChanges []string
 
+	// The relative repository paths of new submodules carved out of this module since its latest release
+	CarvedOutModules []string
+
 	// The change note identifiers applicable for this module
 	ChangeAnnotations []changelog.Annotation
 
@@ -447,36 +590,31 @@ type moduleChangeJSON struct {
 	DependencyUpdate bool `json:"dependency_update,omitempty"`
 }
 
-// buildInverseDependencyGraph builds an inverse dependency graphs mapping a module path to a slice of
-// dependents.
-func buildInverseDependencyGraph(modules map[string]*Module) (reverseDepGraph map[string][]string) {
-	reverseDepGraph = make(map[string][]string)
-
-	for modulePath, mod := range modules {
-		for _, require := range mod.File.Require {
-			requireModPath := require.Mod.Path
-			_, ok := modules[requireModPath]
-			if !ok {
-				continue
-			}
-			reverseDepGraph[requireModPath] = append(reverseDepGraph[requireModPath], modulePath)
-		}
+// newModuleGraph returns the dependency graph of the modules.
+func newModuleGraph(modules map[string]*Module) (*gomod.ModuleGraph, error) {
+	files := make(map[string]*modfile.File, len(modules))
+	for _, module := range modules {
+		files[module.RelativeRepoPath] = module.File
 	}
-
-	return reverseDepGraph
+	return gomod.NewModuleGraph(files)
 }
 
 // CalculateDependencyUpdates determines which modules require a dependency update bump
 // due to one or more of its direct or indirect dependencies being bumped. This will set
-// the DependencyUpdate bit flag on the modules set of changes.
+// the DependencyUpdate bit flag on the modules set of changes. The DependencyUpdateFrom
+// of each updated module is set to its lexically smallest changed direct dependency.
 func CalculateDependencyUpdates(modules map[string]*Module) error {
-	reverseDepGraph := buildInverseDependencyGraph(modules)
-
-	var toVisit []string
-	for modulePath := range reverseDepGraph {
-		toVisit = append(toVisit, modulePath)
+	graph, err := newModuleGraph(modules)
+	if err != nil {
+		return err
 	}
-	sort.Strings(toVisit)
+	return calculateDependencyUpdates(modules, graph)
+}
+
+// calculateDependencyUpdates determines the modules requiring a dependency update bump using the modules' dependency
+// graph.
+func calculateDependencyUpdates(modules map[string]*Module, graph *gomod.ModuleGraph) error {
+	toVisit := graph.Modules()
 
 	var current string
 	for len(toVisit) > 0 {
@@ -488,7 +626,7 @@ func CalculateDependencyUpdates(modules map[string]*Module) error {
 			continue
 		}
 
-		dependents := reverseDepGraph[current]
+		dependents := graph.Dependents(current)
 
 		if m.ModuleConfig.NoTag && len(dependents) > 0 {
 			return fmt.Errorf("module %v is configured for no releases, but has %d dependents", current,
@@ -503,15 +641,59 @@ func CalculateDependencyUpdates(modules map[string]*Module) error {
 				continue
 			}
 			dependentModule.Changes |= DependencyUpdate
-			if _, ok := reverseDepGraph[dependent]; ok {
-				toVisit = repotools.AppendIfNotPresent(toVisit, dependent)
-			}
+			toVisit = repotools.AppendIfNotPresent(toVisit, dependent)
+		}
+	}
+
+	for _, m := range modules {
+		if m.Changes&DependencyUpdate != 0 {
+			m.DependencyUpdateFrom = smallestChangedDependency(modules, m)
 		}
 	}
 
 	return nil
 }
 
+// smallestChangedDependency returns the lexically smallest module path of the module's direct dependencies with
+// changes that are released.
+func smallestChangedDependency(modules map[string]*Module, m *Module) (from string) {
+	for _, require := range m.File.Require {
+		dependency, ok := modules[require.Mod.Path]
+		if !ok || dependency.Changes == 0 || dependency.ModuleConfig.NoTag {
+			continue
+		}
+		if len(from) == 0 || require.Mod.Path < from {
+			from = require.Mod.Path
+		}
+	}
+	return from
+}
+
+// DependencyUpdateChain returns the chain of module paths that caused the DependencyUpdate change for the given
+// module. The chain starts with the module whose source changes required a release, followed by each dependent
+// module, and ends with the given module's direct dependency. Returns nil if the module does not have a
+// DependencyUpdate change.
+func DependencyUpdateChain(modules map[string]*Module, modulePath string) (chain []string) {
+	seen := map[string]struct{}{modulePath: {}}
+
+	current := modules[modulePath]
+	for current != nil && len(current.DependencyUpdateFrom) > 0 {
+		from := current.DependencyUpdateFrom
+		if _, ok := seen[from]; ok {
+			break
+		}
+		seen[from] = struct{}{}
+		chain = append([]string{from}, chain...)
+
+		current = modules[from]
+		if current != nil && current.Changes&(SourceChange|NewModule) != 0 {
+			break
+		}
+	}
+
+	return chain
+}
+
 var nowTime = time.Now
 
 // NextReleaseID returns the next release identifier based on current YYYY-MM-DD and whether there are multiple tags
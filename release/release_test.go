@@ -97,6 +97,59 @@ func TestCalculateNextVersion(t *testing.T) {
 			},
 			wantNext: "v1.1.0",
 		},
+		"existing module version, with breaking annotation": {
+			args: args{
+				modulePath: "github.com/aws/aws-sdk-go-v2/service/existing",
+				latest:     "v1.4.2",
+				annotations: []changelog.Annotation{
+					{Type: changelog.FeatureChangeType},
+					{Type: changelog.BreakingChangeType},
+				},
+			},
+			wantNext: "v2.0.0",
+		},
+		"existing v0 module version, with breaking annotation": {
+			args: args{
+				modulePath: "github.com/aws/aws-sdk-go-v2/service/existing",
+				latest:     "v0.4.2",
+				annotations: []changelog.Annotation{
+					{Type: changelog.BreakingChangeType},
+				},
+			},
+			wantNext: "v1.0.0",
+		},
+		"existing major version module, with breaking annotation and pre-release": {
+			args: args{
+				modulePath:           "github.com/aws/aws-sdk-go-v2/service/existing/v2",
+				latest:               "v2.4.2",
+				preReleaseIdentifier: "rc",
+				annotations: []changelog.Annotation{
+					{Type: changelog.BreakingChangeType},
+				},
+			},
+			wantNext: "v3.0.0-rc",
+		},
+		"existing major pre-release version, with breaking annotation": {
+			args: args{
+				modulePath: "github.com/aws/aws-sdk-go-v2/service/existing/v2",
+				latest:     "v2.0.0-preview",
+				annotations: []changelog.Annotation{
+					{Type: changelog.BreakingChangeType},
+				},
+			},
+			wantNext: "v2.0.0-preview.1",
+		},
+		"existing major pre-release version, with breaking annotation and pre-release": {
+			args: args{
+				modulePath:           "github.com/aws/aws-sdk-go-v2/service/existing/v2",
+				latest:               "v2.0.0-preview",
+				preReleaseIdentifier: "rc",
+				annotations: []changelog.Annotation{
+					{Type: changelog.BreakingChangeType},
+				},
+			},
+			wantNext: "v2.0.0-rc",
+		},
 		"existing module version, set for pre-release": {
 			args: args{
 				modulePath: "github.com/aws/aws-sdk-go-v2/service/existing",
@@ -251,6 +304,48 @@ func TestCalculateNextVersion(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		"version line with minor semver annotation": {
+			args: args{
+				modulePath: "github.com/aws/aws-sdk-go-v2/service/existing",
+				latest:     "v1.24.3",
+				config:     repotools.ModuleConfig{VersionLine: "v1.24"},
+				annotations: []changelog.Annotation{
+					{
+						Type: changelog.FeatureChangeType,
+					},
+				},
+			},
+			wantNext: "v1.24.4",
+		},
+		"version line with pre-release identifier": {
+			args: args{
+				modulePath:           "github.com/aws/aws-sdk-go-v2/service/existing",
+				latest:               "v1.24.3",
+				config:               repotools.ModuleConfig{VersionLine: "v1.24"},
+				preReleaseIdentifier: "rc",
+			},
+			wantNext: "v1.24.4-rc",
+		},
+		"version line with major semver annotation": {
+			args: args{
+				modulePath: "github.com/aws/aws-sdk-go-v2/service/existing",
+				latest:     "v1.24.3",
+				config:     repotools.ModuleConfig{VersionLine: "v1.24"},
+				annotations: []changelog.Annotation{
+					{
+						Type: changelog.BreakingChangeType,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		"version line new module": {
+			args: args{
+				modulePath: "github.com/aws/aws-sdk-go-v2/service/shinynew",
+				config:     repotools.ModuleConfig{VersionLine: "v1.24"},
+			},
+			wantErr: true,
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -388,6 +483,7 @@ require (
 				},
 			},
 			ExpectManifest: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
 				ID:             "2021-10-27",
 				WithReleaseTag: true,
 				Modules: map[string]ModuleManifest{
@@ -441,6 +537,7 @@ require (
 				},
 			},
 			ExpectManifest: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
 				ID:             "2021-10-27",
 				WithReleaseTag: true,
 				Modules: map[string]ModuleManifest{
@@ -459,6 +556,73 @@ require (
 				},
 			},
 		},
+		"multi-module major version upgrade": {
+			ID: "2021-10-27",
+			ModuleTree: func() *gomod.ModuleTree {
+				tree := gomod.NewModuleTree()
+				tree.InsertRel(".")
+				tree.InsertRel("config")
+				return tree
+			}(),
+			Modules: map[string]*Module{
+				"github.com/aws/aws-sdk-go-v2": {
+					File: func() *modfile.File {
+						f, err := gomod.ReadModule("go.mod", strings.NewReader(sdkRootGoMod), nil, false)
+						if err != nil {
+							panic(fmt.Errorf("expect no error reading module, %v", err).Error())
+						}
+						return f
+					}(),
+					RelativeRepoPath: ".",
+					Latest:           "v1.3.0",
+					Changes:          SourceChange,
+					ChangeAnnotations: []changelog.Annotation{
+						{ID: "breaking-change", Type: changelog.BreakingChangeType},
+					},
+				},
+				"github.com/aws/aws-sdk-go-v2/config": {
+					File: func() *modfile.File {
+						f, err := gomod.ReadModule("config/go.mod", strings.NewReader(configGoMod), nil, false)
+						if err != nil {
+							panic(fmt.Errorf("expect no error reading module, %v", err).Error())
+						}
+						return f
+					}(),
+					RelativeRepoPath: "config",
+					Latest:           "v1.0.0",
+					Changes:          DependencyUpdate,
+				},
+			},
+			ExpectManifest: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
+				ID:             "2021-10-27",
+				WithReleaseTag: true,
+				Modules: map[string]ModuleManifest{
+					".": {
+						ModulePath:  "github.com/aws/aws-sdk-go-v2",
+						From:        "v1.3.0",
+						To:          "v2.0.0",
+						Changes:     SourceChange,
+						Annotations: []string{"breaking-change"},
+						MajorVersionUpgrade: &MajorVersionUpgrade{
+							FromModulePath: "github.com/aws/aws-sdk-go-v2",
+							ToModulePath:   "github.com/aws/aws-sdk-go-v2/v2",
+							Dependents:     []string{"config"},
+						},
+					},
+					"config": {
+						ModulePath: "github.com/aws/aws-sdk-go-v2/config",
+						From:       "v1.0.0",
+						To:         "v1.0.1",
+						Changes:    DependencyUpdate,
+					},
+				},
+				Tags: []string{
+					"config/v1.0.1",
+					"v2.0.0",
+				},
+			},
+		},
 		"multi-module no-change": {
 			ID: "2021-10-27",
 			ModuleTree: func() *gomod.ModuleTree {
@@ -492,6 +656,7 @@ require (
 				},
 			},
 			ExpectManifest: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
 				ID:             "2021-10-27",
 				WithReleaseTag: true,
 				Modules:        map[string]ModuleManifest{},
@@ -523,6 +688,7 @@ require (
 				},
 			},
 			ExpectManifest: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
 				ID:             "v1.2.4",
 				WithReleaseTag: false,
 				Modules: map[string]ModuleManifest{
@@ -559,6 +725,7 @@ require (
 				},
 			},
 			ExpectManifest: Manifest{
+				SchemaVersion:  ManifestSchemaVersion,
 				ID:             "v1.2.3",
 				WithReleaseTag: false,
 				Modules:        map[string]ModuleManifest{},
@@ -580,3 +747,77 @@ require (
 		})
 	}
 }
+
+func TestDependencyUpdateChain(t *testing.T) {
+	newModule := func(path string, changes ModuleChange, requires ...string) *Module {
+		f := &modfile.File{}
+		if err := f.AddModuleStmt(path); err != nil {
+			t.Fatal(err)
+		}
+		for _, require := range requires {
+			if err := f.AddRequire(require, "v1.0.0"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return &Module{File: f, RelativeRepoPath: strings.TrimPrefix(path, "example.com/"), Latest: "v1.0.0", Changes: changes}
+	}
+
+	modules := map[string]*Module{
+		"example.com/a": newModule("example.com/a", SourceChange),
+		"example.com/b": newModule("example.com/b", 0, "example.com/a"),
+		"example.com/c": newModule("example.com/c", 0, "example.com/b"),
+		"example.com/d": newModule("example.com/d", SourceChange, "example.com/c"),
+		"example.com/e": newModule("example.com/e", 0),
+	}
+
+	if err := CalculateDependencyUpdates(modules); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	cases := map[string][]string{
+		"example.com/a": nil,
+		"example.com/b": {"example.com/a"},
+		"example.com/c": {"example.com/a", "example.com/b"},
+		"example.com/d": {"example.com/a", "example.com/b", "example.com/c"},
+		"example.com/e": nil,
+	}
+	for modulePath, expect := range cases {
+		if diff := cmp.Diff(expect, DependencyUpdateChain(modules, modulePath)); len(diff) > 0 {
+			t.Errorf("%v: %s", modulePath, diff)
+		}
+	}
+}
+
+func TestDependencyUpdateChainMultipleChangedDependencies(t *testing.T) {
+	newModule := func(path string, changes ModuleChange, requires ...string) *Module {
+		f := &modfile.File{}
+		if err := f.AddModuleStmt(path); err != nil {
+			t.Fatal(err)
+		}
+		for _, require := range requires {
+			if err := f.AddRequire(require, "v1.0.0"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return &Module{File: f, RelativeRepoPath: strings.TrimPrefix(path, "example.com/"), Latest: "v1.0.0", Changes: changes}
+	}
+
+	for i := 0; i < 20; i++ {
+		modules := map[string]*Module{
+			"example.com/a": newModule("example.com/a", SourceChange),
+			"example.com/b": newModule("example.com/b", SourceChange),
+			"example.com/c": newModule("example.com/c", 0, "example.com/b"),
+			"example.com/d": newModule("example.com/d", 0, "example.com/c", "example.com/a"),
+			"example.com/e": newModule("example.com/e", 0, "example.com/d"),
+		}
+
+		if err := CalculateDependencyUpdates(modules); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+
+		expect := []string{"example.com/a", "example.com/d"}
+		if diff := cmp.Diff(expect, DependencyUpdateChain(modules, "example.com/e")); len(diff) > 0 {
+			t.Fatal(diff)
+		}
+	}
+}
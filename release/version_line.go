@@ -0,0 +1,62 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	repotools "github.com/awslabs/aws-go-multi-module-repository-tools"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/changelog"
+	"github.com/awslabs/aws-go-multi-module-repository-tools/internal/semver"
+)
+
+// ParseVersionLine parses a major and minor version line, for example v1.24 or v1.24.x, returning the version line in
+// its canonical form, v1.24.
+func ParseVersionLine(line string) (string, error) {
+	canonical := strings.TrimSuffix(line, ".x")
+	if !semver.IsValid(canonical) || semver.MajorMinor(canonical) != canonical {
+		return "", fmt.Errorf("invalid version line %q, expect major and minor version, (e.g. v1.24)", line)
+	}
+	return canonical, nil
+}
+
+// calculateVersionLineVersion calculates the next patch version of a module released from the configured version line.
+// Minor version increments are released as patch versions, major version increments are not allowed.
+// Examples (VersionLine = "v1.24"):
+//
+//	v1.24.3         => v1.24.4
+//	v1.24.3         => v1.24.4-rc (preReleaseIdentifier = "rc")
+//	v1.24.4-preview => v1.24.4 (increment = ReleaseBump)
+func calculateVersionLineVersion(modulePath, latest string, increment changelog.SemVerIncrement, config repotools.ModuleConfig, preReleaseIdentifier string) (string, error) {
+	if len(latest) == 0 {
+		return "", fmt.Errorf("%v has no release in version line %v", modulePath, config.VersionLine)
+	}
+
+	if increment == changelog.MajorBump {
+		return "", fmt.Errorf("%v major version increment can not be released from version line %v", modulePath, config.VersionLine)
+	}
+	if increment != changelog.ReleaseBump {
+		increment = changelog.PatchBump
+	}
+
+	parsed, ok := semver.Parse(semver.Canonical(latest))
+	if !ok {
+		return "", fmt.Errorf("failed to parse semver: %v, %v", latest, parsed.Err)
+	}
+
+	var next string
+	var err error
+	if len(preReleaseIdentifier) > 0 {
+		next, err = calculatePreReleaseVersion(parsed, increment, config, preReleaseIdentifier)
+	} else {
+		next, err = calculateNextVersion(parsed, increment, config)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if semver.MajorMinor(next) != config.VersionLine {
+		return "", fmt.Errorf("computed next version %s is not within version line %v", next, config.VersionLine)
+	}
+
+	return next, nil
+}
@@ -0,0 +1,35 @@
+package release
+
+import "testing"
+
+func TestParseVersionLine(t *testing.T) {
+	tests := map[string]struct {
+		Line     string
+		Expected string
+		WantErr  bool
+	}{
+		"major minor":         {Line: "v1.24", Expected: "v1.24"},
+		"major minor x patch": {Line: "v1.24.x", Expected: "v1.24"},
+		"major only":          {Line: "v1", WantErr: true},
+		"full version":        {Line: "v1.24.3", WantErr: true},
+		"no prefix":           {Line: "1.24", WantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			line, err := ParseVersionLine(tt.Line)
+			if tt.WantErr {
+				if err == nil {
+					t.Fatal("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if tt.Expected != line {
+				t.Errorf("expect %v, got %v", tt.Expected, line)
+			}
+		})
+	}
+}